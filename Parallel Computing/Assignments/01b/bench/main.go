@@ -0,0 +1,246 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Experiment holds the benchmarks of an experiment spec. Every program is run on every graph for every number of CPUs as often as given.
+// The arguments and environment variables of the programs can use the placeholders {graph}, {cpus} and {run} which are replaced by the values of the current run.
+type Experiment struct {
+	// Graphs holds the graph files.
+	Graphs []string `json:"graphs"`
+	// Runs holds how often a program is run for the same graph and number of CPUs.
+	Runs int `json:"runs"`
+	// Programs holds the programs in the order they are run for each graph. The first program must be the sequential one, since benchstatistic computes the speedups relative to it.
+	Programs []Program `json:"programs"`
+}
+
+// Program holds a program of an experiment.
+type Program struct {
+	// Name holds the name of the program in the CSV file, e.g. "seq".
+	Name string `json:"name"`
+	// Command holds the executable and the arguments of the program.
+	Command []string `json:"command"`
+	// CPUs holds the numbers of CPUs the program is run with. If it is empty, the program is run with one CPU.
+	CPUs []int `json:"cpus"`
+	// Env holds additional environment variables as "KEY=value", e.g. "GOMAXPROCS={cpus}".
+	Env []string `json:"env"`
+	// Affinity holds the CPUs the program is pinned to as a CPU list of taskset, e.g. "576-1023:2". It is also set as GOMP_CPU_AFFINITY, so OpenMP binds each thread to one of the CPUs. The program is not pinned if it is empty.
+	Affinity string `json:"affinity"`
+}
+
+// Measurement holds the resources a single run took.
+type Measurement struct {
+	// Time holds the execution time the program reported itself or, if it does not report it, the wall-clock time.
+	Time time.Duration
+	// Wall holds the wall-clock time of the program.
+	Wall time.Duration
+	// CPUUsage holds the CPU time in user and system mode as percentage of the wall-clock time, as GNU time reports it.
+	CPUUsage int
+	// MinorPagefaults holds the number of page faults which did not need any I/O.
+	MinorPagefaults int64
+	// MaxResident holds the maximum resident set size in kilobytes.
+	MaxResident int64
+}
+
+// executionTime matches the execution time all solver programs print.
+var executionTime = regexp.MustCompile(`Execution took ([0-9.]+) seconds`)
+
+// header holds the columns of the benchmark CSV file. The first columns are the ones benchstatistic reads.
+var header = []string{"File", "Run", "Program", "Number of CPUs", "Time in Seconds", "CPU Usage in Percentage", "Minor Pagefaults", "Wall Time in Seconds", "Maximum Resident Set Size in Kilobytes"}
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Println("Program must be called with <experiment spec file> <benchmark CSV file> as arguments.")
+
+		os.Exit(1)
+	}
+
+	e, err := readExperiment(os.Args[1])
+	if err != nil {
+		fmt.Println(err)
+
+		os.Exit(1)
+	}
+
+	f, err := os.Create(os.Args[2])
+	if err != nil {
+		fmt.Println(err)
+
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	if err := e.run(f, os.Stdout); err != nil {
+		fmt.Println(err)
+
+		os.Exit(1)
+	}
+}
+
+// readExperiment reads in and validates the experiment spec of the given file.
+func readExperiment(filepath string) (*Experiment, error) {
+	data, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	var e Experiment
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("cannot parse experiment spec %q: %v", filepath, err)
+	}
+
+	if len(e.Graphs) == 0 {
+		return nil, errors.New("experiment spec has no graphs")
+	} else if len(e.Programs) == 0 {
+		return nil, errors.New("experiment spec has no programs")
+	} else if e.Runs < 1 {
+		return nil, fmt.Errorf("number of runs must be at least 1 but is %d", e.Runs)
+	}
+	for i, p := range e.Programs {
+		if p.Name == "" {
+			return nil, fmt.Errorf("program %d has no name", i)
+		} else if len(p.Command) == 0 {
+			return nil, fmt.Errorf("program %q has no command", p.Name)
+		}
+		for _, cpus := range p.CPUs {
+			if cpus < 1 {
+				return nil, fmt.Errorf("number of CPUs of program %q must be at least 1 but is %d", p.Name, cpus)
+			}
+		}
+	}
+
+	return &e, nil
+}
+
+// run runs all benchmarks of the experiment and writes a row for each run to the given CSV file. The output of the programs is written to the given log, every run preceded by its marker as in bench.log.
+func (e *Experiment) run(csvFile io.Writer, log io.Writer) error {
+	w := csv.NewWriter(csvFile)
+	w.Comma = ';'
+
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	for _, graph := range e.Graphs {
+		for _, p := range e.Programs {
+			cpuCounts := p.CPUs
+			if len(cpuCounts) == 0 {
+				cpuCounts = []int{1}
+			}
+
+			for _, cpus := range cpuCounts {
+				for run := 1; run <= e.Runs; run++ {
+					marker := fmt.Sprintf("%s run %d %s", graph, run, p.Name)
+					if len(p.CPUs) != 0 {
+						marker += fmt.Sprintf(" %d", cpus)
+					}
+					fmt.Fprintln(log, marker)
+
+					m, err := p.measure(graph, cpus, run, log)
+					if err != nil {
+						return fmt.Errorf("%s: %v", marker, err)
+					}
+
+					if err := w.Write(m.row(graph, run, p.Name, cpus)); err != nil {
+						return err
+					}
+					// Keep the finished runs if the experiment is aborted.
+					w.Flush()
+					if err := w.Error(); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// measure runs the program once with the given graph, number of CPUs and run and returns its resource usage. The output of the program is copied to the given log.
+func (p *Program) measure(graph string, cpus int, run int, log io.Writer) (*Measurement, error) {
+	replacer := strings.NewReplacer("{graph}", graph, "{cpus}", strconv.Itoa(cpus), "{run}", strconv.Itoa(run))
+
+	args := make([]string, len(p.Command))
+	for i, arg := range p.Command {
+		args[i] = replacer.Replace(arg)
+	}
+
+	env := os.Environ()
+	if p.Affinity != "" {
+		// Pin the whole process, since only OpenMP programs read GOMP_CPU_AFFINITY.
+		args = append([]string{"taskset", "--cpu-list", p.Affinity}, args...)
+		env = append(env, "GOMP_CPU_AFFINITY="+p.Affinity)
+	}
+	for _, e := range p.Env {
+		env = append(env, replacer.Replace(e))
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Env = env
+
+	var output bytes.Buffer
+	cmd.Stdout = io.MultiWriter(&output, log)
+	cmd.Stderr = log
+
+	start := time.Now()
+	err := cmd.Run()
+	wall := time.Since(start)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Measurement{
+		Time: wall,
+		Wall: wall,
+	}
+	if match := executionTime.FindSubmatch(output.Bytes()); match != nil {
+		seconds, err := strconv.ParseFloat(string(match[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid execution time %q", match[1])
+		}
+
+		m.Time = time.Duration(seconds * float64(time.Second))
+	}
+
+	cpu := cmd.ProcessState.UserTime() + cmd.ProcessState.SystemTime()
+	if wall > 0 {
+		m.CPUUsage = int(100 * cpu / wall)
+	}
+	if usage, ok := cmd.ProcessState.SysUsage().(*syscall.Rusage); ok {
+		m.MinorPagefaults = usage.Minflt
+		// Linux reports the maximum resident set size in kilobytes already.
+		m.MaxResident = usage.Maxrss
+	}
+
+	return m, nil
+}
+
+// row returns the CSV row of the measurement of the given run in the columns of header.
+func (m *Measurement) row(graph string, run int, program string, cpus int) []string {
+	return []string{
+		graph,
+		strconv.Itoa(run),
+		program,
+		strconv.Itoa(cpus),
+		strconv.FormatFloat(m.Time.Seconds(), 'f', 7, 64),
+		strconv.Itoa(m.CPUUsage),
+		strconv.FormatInt(m.MinorPagefaults, 10),
+		strconv.FormatFloat(m.Wall.Seconds(), 'f', 7, 64),
+		strconv.FormatInt(m.MaxResident, 10),
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadExperiment(t *testing.T) {
+	directory, err := ioutil.TempDir("", "bench")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+
+	for name, tc := range map[string]struct {
+		content  string
+		expected *Experiment
+		err      string
+	}{
+		"valid": {
+			`{"graphs": ["a.graph", "b.graph"], "runs": 2, "programs": [{"name": "seq", "command": ["./seq", "{graph}"], "affinity": "0-3"}, {"name": "gopal", "command": ["./gopal", "{cpus}"], "cpus": [1, 2], "env": ["GOMAXPROCS={cpus}"]}]}`,
+			&Experiment{
+				Graphs: []string{"a.graph", "b.graph"},
+				Runs:   2,
+				Programs: []Program{
+					{Name: "seq", Command: []string{"./seq", "{graph}"}, Affinity: "0-3"},
+					{Name: "gopal", Command: []string{"./gopal", "{cpus}"}, CPUs: []int{1, 2}, Env: []string{"GOMAXPROCS={cpus}"}},
+				},
+			},
+			"",
+		},
+		"invalid JSON": {
+			`{"graphs": [`,
+			nil,
+			`cannot parse experiment spec "{file}": unexpected end of JSON input`,
+		},
+		"no graphs": {
+			`{"runs": 1, "programs": [{"name": "seq", "command": ["./seq"]}]}`,
+			nil,
+			"experiment spec has no graphs",
+		},
+		"no programs": {
+			`{"graphs": ["a.graph"], "runs": 1}`,
+			nil,
+			"experiment spec has no programs",
+		},
+		"no runs": {
+			`{"graphs": ["a.graph"], "programs": [{"name": "seq", "command": ["./seq"]}]}`,
+			nil,
+			"number of runs must be at least 1 but is 0",
+		},
+		"no name": {
+			`{"graphs": ["a.graph"], "runs": 1, "programs": [{"command": ["./seq"]}]}`,
+			nil,
+			"program 0 has no name",
+		},
+		"no command": {
+			`{"graphs": ["a.graph"], "runs": 1, "programs": [{"name": "seq"}]}`,
+			nil,
+			`program "seq" has no command`,
+		},
+		"no CPUs": {
+			`{"graphs": ["a.graph"], "runs": 1, "programs": [{"name": "pal", "command": ["./pal"], "cpus": [1, 0]}]}`,
+			nil,
+			`number of CPUs of program "pal" must be at least 1 but is 0`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(directory, name+".json")
+			assert.NoError(t, ioutil.WriteFile(file, []byte(tc.content), 0644))
+
+			e, err := readExperiment(file)
+			if tc.err != "" {
+				assert.EqualError(t, err, strings.Replace(tc.err, "{file}", file, 1))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, e)
+		})
+	}
+
+	_, err = readExperiment(filepath.Join(directory, "missing.json"))
+	assert.Error(t, err)
+}
+
+// lockedBuffer is a buffer which can be written concurrently, since the output and the errors of a program are copied to the log concurrently.
+type lockedBuffer struct {
+	sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.Lock()
+	defer b.Unlock()
+
+	return b.buffer.Write(p)
+}
+
+func TestMeasureRow(t *testing.T) {
+	for name, tc := range map[string]struct {
+		program Program
+		output  string
+		time    string
+	}{
+		"reported time": {
+			Program{Name: "seq", Command: []string{"sh", "-c", "echo 'The shortest path has length 7'; echo 'Execution took {run}.25 seconds'", "{graph}"}},
+			"The shortest path has length 7\nExecution took 3.25 seconds\n",
+			"3.2500000",
+		},
+		"placeholders": {
+			Program{Name: "gopal", Command: []string{"sh", "-c", "echo $0 $1 $RUN", "{graph}", "{cpus}"}, Env: []string{"RUN={run}"}},
+			"a.graph 2 3\n",
+			"",
+		},
+		"affinity": {
+			Program{Name: "pal", Command: []string{"sh", "-c", "echo $GOMP_CPU_AFFINITY; grep Cpus_allowed_list /proc/self/status"}, Affinity: "0"},
+			"0\nCpus_allowed_list:\t0\n",
+			"",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var log lockedBuffer
+			m, err := tc.program.measure("a.graph", 2, 3, &log)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.output, log.buffer.String())
+
+			row := m.row("a.graph", 3, tc.program.Name, 2)
+			assert.Len(t, row, len(header))
+			assert.Equal(t, []string{"a.graph", "3", tc.program.Name, "2"}, row[:4])
+			if tc.time != "" {
+				assert.Equal(t, tc.time, row[4])
+			} else {
+				// Without a reported execution time the wall-clock time is used.
+				assert.Equal(t, row[7], row[4])
+			}
+		})
+	}
+
+	_, err := (&Program{Name: "false", Command: []string{"false"}}).measure("a.graph", 1, 1, ioutil.Discard)
+	assert.EqualError(t, err, "exit status 1")
+}
+
+func TestRow(t *testing.T) {
+	m := &Measurement{
+		Time:            1500 * time.Millisecond,
+		Wall:            2 * time.Second,
+		CPUUsage:        190,
+		MinorPagefaults: 232,
+		MaxResident:     4096,
+	}
+
+	assert.Equal(t, []string{"graphs/01-original.graph", "5", "pal", "16", "1.5000000", "190", "232", "2.0000000", "4096"}, m.row("graphs/01-original.graph", 5, "pal", 16))
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// marker matches the line bench.sh echoes before every run, e.g. "graphs/01-original.graph run 1 pal 16". The sequential program has no number of CPUs.
+	marker = regexp.MustCompile(`^(\S+) run (\d+) (\S+?)(?: (\d+))?$`)
+	// executionTime matches the execution time all solver programs print.
+	executionTime = regexp.MustCompile(`^Execution took ([0-9.]+) seconds$`)
+	// resources matches the first line of the resource usage GNU time prints, e.g. "0.00user 0.00system 0:00.00elapsed 57%CPU (0avgtext+0avgdata 3504maxresident)k".
+	resources = regexp.MustCompile(`^[0-9.]+user [0-9.]+system ([0-9:.]+)elapsed (\d+|\?)%CPU \(\d+avgtext\+\d+avgdata (\d+)maxresident\)k$`)
+	// pagefaults matches the second line of the resource usage GNU time prints, e.g. "0inputs+8outputs (0major+272minor)pagefaults 0swaps".
+	pagefaults = regexp.MustCompile(`^\d+inputs\+\d+outputs \(\d+major\+(\d+)minor\)pagefaults \d+swaps$`)
+)
+
+// header holds the columns of the benchmark CSV file as the bench command writes them. The first columns are the ones benchstatistic reads.
+var header = []string{"File", "Run", "Program", "Number of CPUs", "Time in Seconds", "CPU Usage in Percentage", "Minor Pagefaults", "Wall Time in Seconds", "Maximum Resident Set Size in Kilobytes"}
+
+// run holds what has been read of a run of bench.log so far.
+type run struct {
+	line int
+
+	file    string
+	run     string
+	program string
+	cpus    string
+
+	time            string
+	result          bool
+	cpuUsage        string
+	wall            string
+	maxResident     string
+	minorPagefaults string
+	done            bool
+}
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Println("Program must be called with <benchmark log file> <benchmark CSV file> as arguments.")
+
+		os.Exit(1)
+	}
+
+	in, err := os.Open(os.Args[1])
+	if err != nil {
+		fmt.Println(err)
+
+		os.Exit(1)
+	}
+	defer in.Close()
+
+	runs, err := parseLog(in)
+	if err != nil {
+		fmt.Printf("%s: %v\n", os.Args[1], err)
+
+		os.Exit(1)
+	}
+
+	out, err := os.Create(os.Args[2])
+	if err != nil {
+		fmt.Println(err)
+
+		os.Exit(1)
+	}
+
+	w := csv.NewWriter(out)
+	w.Comma = ';'
+
+	// Write errors are reported by Error after the flush.
+	_ = w.Write(header)
+	for _, r := range runs {
+		_ = w.Write(r.row())
+	}
+	w.Flush()
+
+	if err := w.Error(); err != nil {
+		fmt.Println(err)
+
+		os.Exit(1)
+	} else if err := out.Close(); err != nil {
+		fmt.Println(err)
+
+		os.Exit(1)
+	}
+
+	fmt.Printf("Converted %d runs\n", len(runs))
+}
+
+// parseLog reads in the runs of the given bench.log. Every run must have printed a tour or that there is no cyclic path, and the resource usage of GNU time.
+func parseLog(r io.Reader) ([]*run, error) {
+	var runs []*run
+	var current *run
+
+	// finish validates the current run.
+	finish := func() error {
+		if current == nil {
+			return nil
+		} else if !current.result {
+			return fmt.Errorf("line %d: run did not print a tour or that there is no cyclic path", current.line)
+		} else if !current.done {
+			return fmt.Errorf("line %d: run has no resource usage of GNU time", current.line)
+		}
+
+		if current.time == "" {
+			// Programs which do not report their execution time are measured by their wall-clock time.
+			current.time = current.wall
+		}
+		runs = append(runs, current)
+
+		return nil
+	}
+
+	s := bufio.NewScanner(r)
+	line := 0
+	for s.Scan() {
+		line++
+		text := strings.TrimSpace(s.Text())
+
+		// Skip the commands traced by "set -x".
+		if text == "" || strings.HasPrefix(text, "+") {
+			continue
+		}
+
+		if m := marker.FindStringSubmatch(text); m != nil {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+
+			current = &run{
+				line:    line,
+				file:    m[1],
+				run:     m[2],
+				program: m[3],
+				cpus:    m[4],
+			}
+			if current.cpus == "" {
+				current.cpus = "1"
+			}
+
+			continue
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("line %d: output %q before the first run", line, text)
+		} else if current.done {
+			return nil, fmt.Errorf("line %d: output %q after the resource usage of the run", line, text)
+		}
+
+		if m := executionTime.FindStringSubmatch(text); m != nil {
+			seconds, err := strconv.ParseFloat(m[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid execution time %q", line, m[1])
+			}
+
+			current.time = strconv.FormatFloat(seconds, 'f', 7, 64)
+		} else if strings.HasPrefix(text, "The shortest path has length ") || text == "There is no cyclic path" {
+			current.result = true
+		} else if m := resources.FindStringSubmatch(text); m != nil {
+			wall, err := parseElapsed(m[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", line, err)
+			}
+
+			current.wall = strconv.FormatFloat(wall, 'f', 7, 64)
+			current.cpuUsage = m[2]
+			if current.cpuUsage == "?" {
+				// GNU time cannot compute the percentage if no time elapsed.
+				current.cpuUsage = "0"
+			}
+			current.maxResident = m[3]
+		} else if m := pagefaults.FindStringSubmatch(text); m != nil {
+			if current.wall == "" {
+				return nil, fmt.Errorf("line %d: page faults without the preceding resource usage", line)
+			}
+
+			current.minorPagefaults = m[1]
+			current.done = true
+		} else if strings.HasPrefix(text, "Command exited with non-zero status") || strings.HasPrefix(text, "Command terminated by signal") {
+			return nil, fmt.Errorf("line %d: run failed: %s", line, text)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+// row returns the CSV row of the run in the columns of header.
+func (r *run) row() []string {
+	return []string{r.file, r.run, r.program, r.cpus, r.time, r.cpuUsage, r.minorPagefaults, r.wall, r.maxResident}
+}
+
+// parseElapsed parses the elapsed time of GNU time, which is given as "[hours:]minutes:seconds", and returns it in seconds.
+func parseElapsed(elapsed string) (float64, error) {
+	parts := strings.Split(elapsed, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid elapsed time %q", elapsed)
+	}
+
+	var seconds float64
+	for _, part := range parts {
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid elapsed time %q", elapsed)
+		}
+
+		seconds = 60*seconds + v
+	}
+
+	return seconds, nil
+}
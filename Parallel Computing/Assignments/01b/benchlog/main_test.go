@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLog(t *testing.T) {
+	for name, tc := range map[string]struct {
+		log      string
+		expected [][]string
+		err      string
+	}{
+		"normal run": {
+			"+ echo 'graphs/01-original.graph run 1 seq'\ngraphs/01-original.graph run 1 seq\n+ time ./sequential graphs/01-original.graph\nExecution took 0.0000067 seconds\nThe shortest path has length 15 with the path 0->3->1->2->0\n0.00user 0.00system 0:00.00elapsed 0%CPU (0avgtext+0avgdata 2864maxresident)k\n0inputs+8outputs (0major+232minor)pagefaults 0swaps\n" +
+				"graphs/01-original.graph run 1 pal 16\nThe shortest path has length 15 with the path 0->3->1->2->0\n0.01user 0.02system 1:02.50elapsed ?%CPU (0avgtext+0avgdata 3504maxresident)k\n0inputs+0outputs (0major+272minor)pagefaults 0swaps\n",
+			[][]string{
+				{"graphs/01-original.graph", "1", "seq", "1", "0.0000067", "0", "232", "0.0000000", "2864"},
+				{"graphs/01-original.graph", "1", "pal", "16", "62.5000000", "0", "272", "62.5000000", "3504"},
+			},
+			"",
+		},
+		"no-path run": {
+			"graphs/no-path.graph run 2 seq\nExecution took 0.5 seconds\nThere is no cyclic path\n0.49user 0.00system 0:00.50elapsed 98%CPU (0avgtext+0avgdata 2864maxresident)k\n0inputs+8outputs (0major+232minor)pagefaults 0swaps\n",
+			[][]string{
+				{"graphs/no-path.graph", "2", "seq", "1", "0.5000000", "98", "232", "0.5000000", "2864"},
+			},
+			"",
+		},
+		"missing rusage": {
+			"graphs/01-original.graph run 1 seq\nThe shortest path has length 15 with the path 0->3->1->2->0\ngraphs/01-original.graph run 2 seq\nThe shortest path has length 15 with the path 0->3->1->2->0\n0.00user 0.00system 0:00.00elapsed 0%CPU (0avgtext+0avgdata 2864maxresident)k\n0inputs+8outputs (0major+232minor)pagefaults 0swaps\n",
+			nil,
+			"line 1: run has no resource usage of GNU time",
+		},
+		"truncated run": {
+			"graphs/01-original.graph run 1 seq\nExecution took 0.0000067 seconds\n",
+			nil,
+			"line 1: run did not print a tour or that there is no cyclic path",
+		},
+		"truncated resource usage": {
+			"graphs/01-original.graph run 1 seq\nThe shortest path has length 15 with the path 0->3->1->2->0\n0.00user 0.00system 0:00.00elapsed 0%CPU (0avgtext+0avgdata 2864maxresident)k\n",
+			nil,
+			"line 1: run has no resource usage of GNU time",
+		},
+		"failed run": {
+			"graphs/01-original.graph run 1 seq\nCommand terminated by signal 9\n",
+			nil,
+			"line 2: run failed: Command terminated by signal 9",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			runs, err := parseLog(strings.NewReader(tc.log))
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+
+				return
+			}
+			assert.NoError(t, err)
+
+			var rows [][]string
+			for _, r := range runs {
+				rows = append(rows, r.row())
+			}
+			assert.Equal(t, tc.expected, rows)
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/zimmski/jku/parallel-computing/assignments/01b/tsp"
+)
+
+// Options holds the command line options shared by the solver programs.
+type Options struct {
+	// GraphFile holds the filepath to the graph file.
+	GraphFile string
+	// Timeout holds the wall-clock time limit of the search. Zero means no limit.
+	Timeout time.Duration
+	// Progress enables printing every improved tour during the search.
+	Progress bool
+	// Bound holds the lower bound which is used to prune paths.
+	Bound string
+	// Algorithm holds the algorithm which is used to solve the graph.
+	Algorithm string
+	// Heuristic holds the construction heuristic for the initial tour.
+	Heuristic string
+	// Improve enables improving the initial tour with 2-opt and Or-opt moves.
+	Improve bool
+	// TourFile holds the filepath to a known tour which is used as initial tour.
+	TourFile string
+	// Format holds the format in which the result is printed.
+	Format string
+	// CPUProfile holds the filepath the CPU profile of the search is written to if it is not empty.
+	CPUProfile string
+	// MemProfile holds the filepath the heap profile at the end of the search is written to if it is not empty.
+	MemProfile string
+	// BlockProfile holds the filepath the profile of blocking goroutines during the search is written to if it is not empty.
+	BlockProfile string
+	// MutexProfile holds the filepath the profile of contended mutexes during the search is written to if it is not empty.
+	MutexProfile string
+	// Trace holds the filepath the execution trace of the search is written to if it is not empty.
+	Trace string
+
+	// graph holds the graph which is solved by Run.
+	graph *tsp.Graph
+	// start holds the time at which Run has started the search.
+	start time.Time
+}
+
+const (
+	// AlgorithmDFS solves graphs with the branch-and-bound depth-first search.
+	AlgorithmDFS = "dfs"
+	// AlgorithmHeldKarp solves graphs with the dynamic programming algorithm of Held and Karp.
+	AlgorithmHeldKarp = "heldkarp"
+)
+
+// RegisterFlags registers the shared options as flags of the given flag set.
+func (o *Options) RegisterFlags(flags *flag.FlagSet) {
+	flags.DurationVar(&o.Timeout, "timeout", 0, "stop the search after the given duration and report the best path found so far, e.g. \"90s\" or \"2h\"")
+	flags.BoolVar(&o.Progress, "progress", false, "print every improved path as soon as it is found")
+
+	bounds := make([]string, len(tsp.Bounds))
+	for i, b := range tsp.Bounds {
+		bounds[i] = string(b)
+	}
+	flags.StringVar(&o.Algorithm, "algorithm", AlgorithmDFS, "algorithm which is used to solve the graph, one of "+AlgorithmDFS+", "+AlgorithmHeldKarp)
+	flags.StringVar(&o.Bound, "bound", string(tsp.BoundNone), "lower bound which is used to prune paths, one of "+strings.Join(bounds, ", "))
+
+	heuristics := make([]string, len(tsp.Heuristics))
+	for i, h := range tsp.Heuristics {
+		heuristics[i] = string(h)
+	}
+	flags.StringVar(&o.Heuristic, "warmstart", string(tsp.HeuristicNone), "heuristic which constructs the initial tour that bounds the search from the start, one of "+strings.Join(heuristics, ", "))
+	flags.BoolVar(&o.Improve, "improve", false, "improve the initial tour of the warm start heuristic with 2-opt and Or-opt moves")
+	flags.StringVar(&o.TourFile, "tour", "", "filepath to a known tour which is used as initial tour, e.g. \"0->3->1->2->0\"")
+	flags.StringVar(&o.Format, "format", FormatText, "format in which the result is printed, one of "+FormatText+", "+FormatJSON+", "+FormatCSV)
+
+	flags.StringVar(&o.CPUProfile, "cpuprofile", "", "write the CPU profile of the search to the given file, view it with \"go tool pprof\"")
+	flags.StringVar(&o.MemProfile, "memprofile", "", "write the heap profile at the end of the search to the given file")
+	flags.StringVar(&o.BlockProfile, "blockprofile", "", "write the profile of blocking goroutines during the search to the given file")
+	flags.StringVar(&o.MutexProfile, "mutexprofile", "", "write the profile of contended mutexes during the search to the given file")
+	flags.StringVar(&o.Trace, "trace", "", "write the execution trace of the search to the given file, view it with \"go tool trace\"")
+}
+
+// CheckAlgorithm returns an error if the algorithm is not one of the given algorithms supported by the program or if it does not support the given options. Held-Karp has no tour before its table is complete, so it can neither prune with a bound or an initial tour nor report progress.
+func (o *Options) CheckAlgorithm(algorithms ...string) error {
+	supported := false
+	for _, a := range algorithms {
+		if o.Algorithm == a {
+			supported = true
+
+			break
+		}
+	}
+	if !supported {
+		return fmt.Errorf("algorithm %q is not supported, must be one of %s", o.Algorithm, strings.Join(algorithms, ", "))
+	}
+
+	if o.Algorithm != AlgorithmHeldKarp {
+		return nil
+	}
+
+	var unsupported []string
+	if o.Bound != "" && o.Bound != string(tsp.BoundNone) {
+		unsupported = append(unsupported, "-bound")
+	}
+	if o.Heuristic != "" && o.Heuristic != string(tsp.HeuristicNone) {
+		unsupported = append(unsupported, "-warmstart")
+	}
+	if o.Improve {
+		unsupported = append(unsupported, "-improve")
+	}
+	if o.Progress {
+		unsupported = append(unsupported, "-progress")
+	}
+	if o.TourFile != "" {
+		unsupported = append(unsupported, "-tour")
+	}
+	if len(unsupported) != 0 {
+		return fmt.Errorf("algorithm %q does not support %s", o.Algorithm, strings.Join(unsupported, ", "))
+	}
+
+	return nil
+}
+
+// CheckFlags returns an error if the algorithm is the given one and any of the given flags of the program has been set, since the algorithm does not support them.
+func (o *Options) CheckFlags(flags *flag.FlagSet, algorithm string, names ...string) error {
+	if o.Algorithm != algorithm {
+		return nil
+	}
+
+	var unsupported []string
+	flags.Visit(func(f *flag.Flag) {
+		for _, name := range names {
+			if f.Name == name {
+				unsupported = append(unsupported, "-"+name)
+			}
+		}
+	})
+	if len(unsupported) != 0 {
+		return fmt.Errorf("algorithm %q does not support %s", o.Algorithm, strings.Join(unsupported, ", "))
+	}
+
+	return nil
+}
+
+// WarmStart returns the warm start for the solver or nil if no initial tour is requested.
+func (o *Options) WarmStart() (tsp.WarmStart, error) {
+	var warmStarts []tsp.WarmStart
+
+	heuristic, err := tsp.HeuristicWarmStart(tsp.Heuristic(o.Heuristic), o.Improve)
+	if err != nil {
+		return nil, err
+	} else if heuristic != nil {
+		warmStarts = append(warmStarts, heuristic)
+	}
+	if o.TourFile != "" {
+		warmStarts = append(warmStarts, tsp.TourFileWarmStart(o.TourFile))
+	}
+
+	switch len(warmStarts) {
+	case 0:
+		return nil, nil
+	case 1:
+		return warmStarts[0], nil
+	}
+
+	return tsp.BestWarmStart(warmStarts...), nil
+}
+
+// ProgressFunc returns the progress function for the solver or nil if progress is not enabled.
+func (o *Options) ProgressFunc() tsp.ProgressFunc {
+	if !o.Progress {
+		return nil
+	}
+
+	return o.printProgress
+}
+
+// Run reads in the graph, solves it with the given solver and prints the result. It returns the exit code for the program.
+// The first interrupt signal stops the search like a timeout does, a second one kills the program.
+func (o *Options) Run(solver tsp.Solver) int {
+	if o.Format == "" {
+		o.Format = FormatText
+	} else if err := validateFormat(o.Format); err != nil {
+		fmt.Println(err)
+
+		return 1
+	}
+
+	readStart := time.Now()
+	g, err := tsp.ReadGraph(o.GraphFile)
+	if err != nil {
+		fmt.Println(err)
+
+		return 1
+	}
+	read := time.Since(readStart)
+	o.graph = g
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if o.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	go func() {
+		if _, ok := <-interrupt; ok {
+			signal.Stop(interrupt)
+			cancel()
+		}
+	}()
+	defer func() {
+		signal.Stop(interrupt)
+		close(interrupt)
+	}()
+
+	stopProfiles, err := o.StartProfiles()
+	if err != nil {
+		fmt.Println(err)
+
+		return 1
+	}
+
+	printHeader(o.Format)
+
+	o.start = time.Now()
+	winner, err := solver.Solve(ctx, g)
+	elapsed := time.Since(o.start)
+
+	if err := stopProfiles(); err != nil {
+		fmt.Println(err)
+
+		return 1
+	}
+	if err != nil {
+		fmt.Println(err)
+
+		return 1
+	}
+
+	// Solvers which do not measure their setup take all of the time for the search.
+	setup, search := read+winner.Setup, winner.Search
+	if winner.Setup == 0 && winner.Search == 0 {
+		search = elapsed
+	}
+
+	if o.Format == FormatText {
+		// Print the execution time as the C programs do, since bench.log relies on it.
+		fmt.Printf("Execution took %0.7f seconds\n", search.Seconds())
+		printTour(g, winner)
+		fmt.Printf("Setup took %0.7f seconds of which reading the graph took %0.7f seconds\n", setup.Seconds(), read.Seconds())
+	} else {
+		r := newResult("result", g, winner, &winner.Stats, elapsed)
+		r.ReadSeconds = read.Seconds()
+		r.SetupSeconds = setup.Seconds()
+		r.SearchSeconds = search.Seconds()
+		r.Workers = 1
+		if len(winner.Stats.Workers) != 0 {
+			r.Workers = len(winner.Stats.Workers)
+		}
+		for i := range winner.Stats.Workers {
+			r.WorkerStats = append(r.WorkerStats, newStatsResult(&winner.Stats.Workers[i]))
+		}
+
+		printResult(o.Format, r)
+	}
+
+	return 0
+}
+
+// printTour prints the given tour of the given graph to STDOUT.
+func printTour(g *tsp.Graph, t *tsp.Tour) {
+	if t.Optimal {
+		if t.Order == nil {
+			fmt.Println("There is no cyclic path")
+		} else {
+			fmt.Printf("The shortest path has length %d with the path %s\n", t.Length, t.LabeledString(g))
+		}
+	} else {
+		if t.Order == nil {
+			fmt.Println("The search was stopped before a cyclic path was found")
+		} else {
+			fmt.Printf("The search was stopped, the best path found so far has length %d with the path %s and is not proven optimal\n", t.Length, t.LabeledString(g))
+		}
+	}
+
+	fmt.Printf("Expanded %d paths and pruned %d paths\n", t.Stats.Expanded, t.Stats.Pruned)
+	fmt.Printf("Pushed %d paths, completed %d cyclic paths and improved the best path %d times\n", t.Stats.Pushed, t.Stats.Completed, t.Stats.Improved)
+	if t.Stats.StalePruned != 0 {
+		fmt.Printf("Pruned %d paths which the best path found by the pruning worker itself would have kept\n", t.Stats.StalePruned)
+	}
+	if t.Stats.QueueLocks != 0 {
+		fmt.Printf("Locked the queue %d times and waited %0.7f seconds for it\n", t.Stats.QueueLocks, t.Stats.QueueWait.Seconds())
+	}
+	for i, w := range t.Stats.Workers {
+		fmt.Printf("Worker %d expanded %d paths, pruned %d paths of which %d only with the best path of all workers and stole %d paths\n", i, w.Expanded, w.Pruned, w.StalePruned, w.Steals)
+		fmt.Printf("Worker %d pushed %d paths, completed %d cyclic paths, improved the best path %d times and waited %0.7f seconds for %d queue locks\n", i, w.Pushed, w.Completed, w.Improved, w.QueueWait.Seconds(), w.QueueLocks)
+	}
+}
+
+// printProgress prints the given improved tour to STDOUT.
+func (o *Options) printProgress(p tsp.Progress) {
+	if o.Format != FormatText && o.Format != "" {
+		printResult(o.Format, newResult("progress", o.graph, p.Tour, &p.Stats, p.Elapsed))
+
+		return
+	}
+
+	fmt.Printf("Improved path with length %d after %0.7f seconds and %d expanded paths: %s\n", p.Tour.Length, p.Elapsed.Seconds(), p.Expanded, p.Tour.LabeledString(o.graph))
+}
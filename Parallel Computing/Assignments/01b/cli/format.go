@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zimmski/jku/parallel-computing/assignments/01b/tsp"
+)
+
+const (
+	// FormatText prints the result as sentences.
+	FormatText = "text"
+	// FormatJSON prints every improved tour and the result as one JSON object per line.
+	FormatJSON = "json"
+	// FormatCSV prints a header and every improved tour and the result as one row separated by semicolons.
+	FormatCSV = "csv"
+)
+
+// result holds a tour as it is printed by the machine-readable formats.
+type result struct {
+	// Event is "progress" for an improved tour during the search and "result" for the tour at the end of the search.
+	Event string `json:"event"`
+	// Length holds the length of the tour, it is nil if there is no tour.
+	Length *int `json:"length"`
+	// Tour holds the nodes of the tour beginning with the start node, it is nil if there is no tour.
+	Tour []int `json:"tour"`
+	// Path holds the tour as printed by the text format using the labels of the nodes.
+	Path string `json:"path"`
+	// Optimal is true if the tour is proven to be the shortest one or, if there is no tour, that there is none.
+	Optimal bool `json:"optimal"`
+	// Seconds holds the wall-clock time since the search has been started.
+	Seconds float64 `json:"seconds"`
+	// ReadSeconds holds the wall-clock time it took to read the graph. It is only known at the end of the search.
+	ReadSeconds float64 `json:"readSeconds"`
+	// SetupSeconds holds the wall-clock time it took to read the graph and to prepare the search. It is only known at the end of the search.
+	SetupSeconds float64 `json:"setupSeconds"`
+	// SearchSeconds holds the wall-clock time of the search without its setup. It is only known at the end of the search.
+	SearchSeconds float64 `json:"searchSeconds"`
+	statsResult
+	// Workers holds the number of workers of the search. It is only known at the end of the search.
+	Workers int `json:"workers"`
+	// WorkerStats holds the statistics of every worker if the solver reports them. They are only known at the end of the search.
+	WorkerStats []statsResult `json:"workerStats"`
+}
+
+// statsResult holds the statistics of a search or of one of its workers as they are printed by the machine-readable formats. The fields are described by tsp.Stats.
+type statsResult struct {
+	Expanded         int64   `json:"expanded"`
+	Pushed           int64   `json:"pushed"`
+	Pruned           int64   `json:"pruned"`
+	StalePruned      int64   `json:"stalePruned"`
+	Completed        int64   `json:"completed"`
+	Improved         int64   `json:"improved"`
+	Steals           int64   `json:"steals"`
+	QueueLocks       int64   `json:"queueLocks"`
+	QueueWaitSeconds float64 `json:"queueWaitSeconds"`
+}
+
+// newStatsResult returns the result for the given statistics.
+func newStatsResult(s *tsp.Stats) statsResult {
+	return statsResult{
+		Expanded:         s.Expanded,
+		Pushed:           s.Pushed,
+		Pruned:           s.Pruned,
+		StalePruned:      s.StalePruned,
+		Completed:        s.Completed,
+		Improved:         s.Improved,
+		Steals:           s.Steals,
+		QueueLocks:       s.QueueLocks,
+		QueueWaitSeconds: s.QueueWait.Seconds(),
+	}
+}
+
+// columns returns the statistics as columns of the CSV format.
+func (s *statsResult) columns() []string {
+	return []string{
+		strconv.FormatInt(s.Expanded, 10),
+		strconv.FormatInt(s.Pushed, 10),
+		strconv.FormatInt(s.Pruned, 10),
+		strconv.FormatInt(s.StalePruned, 10),
+		strconv.FormatInt(s.Completed, 10),
+		strconv.FormatInt(s.Improved, 10),
+		strconv.FormatInt(s.Steals, 10),
+		strconv.FormatInt(s.QueueLocks, 10),
+		strconv.FormatFloat(s.QueueWaitSeconds, 'f', 7, 64),
+	}
+}
+
+// statsHeader holds the columns of the statistics in the CSV format.
+var statsHeader = []string{"Expanded", "Pushed", "Pruned", "Stale Pruned", "Completed", "Improved", "Steals", "Queue Locks", "Queue Wait in Seconds"}
+
+// csvHeader returns the columns of the CSV format. The statistics of the workers follow the statistics of the search, every column of them holds the values of all workers separated by spaces.
+func csvHeader() []string {
+	header := []string{"Event", "Length", "Tour", "Path", "Optimal", "Seconds", "Read Seconds", "Setup Seconds", "Search Seconds"}
+	header = append(header, statsHeader...)
+	header = append(header, "Workers")
+	for _, column := range statsHeader {
+		header = append(header, "Worker "+column)
+	}
+
+	return header
+}
+
+// newResult returns the result for the given tour of the given graph with the given statistics.
+func newResult(event string, g *tsp.Graph, t *tsp.Tour, stats *tsp.Stats, elapsed time.Duration) *result {
+	r := &result{
+		Event:       event,
+		Path:        t.LabeledString(g),
+		Optimal:     t.Optimal,
+		Seconds:     elapsed.Seconds(),
+		statsResult: newStatsResult(stats),
+	}
+	if t.Order != nil {
+		length := t.Length
+		r.Length = &length
+		r.Tour = t.Order
+	}
+
+	return r
+}
+
+// validateFormat returns an error if the given format is unknown.
+func validateFormat(format string) error {
+	switch format {
+	case FormatText, FormatJSON, FormatCSV:
+		return nil
+	}
+
+	return fmt.Errorf("unknown format %q", format)
+}
+
+// printHeader prints the header of the given format to STDOUT if it has one.
+func printHeader(format string) {
+	if format == FormatCSV {
+		writeCSV(csvHeader())
+	}
+}
+
+// printResult prints the given result in the given machine-readable format to STDOUT.
+func printResult(format string, r *result) {
+	switch format {
+	case FormatJSON:
+		e := json.NewEncoder(os.Stdout)
+		// Keep the arrows of the path readable.
+		e.SetEscapeHTML(false)
+		// Writing to STDOUT can only fail if STDOUT is gone.
+		_ = e.Encode(r)
+	case FormatCSV:
+		var length string
+		if r.Length != nil {
+			length = strconv.Itoa(*r.Length)
+		}
+		tour := make([]string, len(r.Tour))
+		for i, node := range r.Tour {
+			tour[i] = strconv.Itoa(node)
+		}
+
+		row := []string{
+			r.Event,
+			length,
+			strings.Join(tour, " "),
+			r.Path,
+			strconv.FormatBool(r.Optimal),
+			strconv.FormatFloat(r.Seconds, 'f', 7, 64),
+			strconv.FormatFloat(r.ReadSeconds, 'f', 7, 64),
+			strconv.FormatFloat(r.SetupSeconds, 'f', 7, 64),
+			strconv.FormatFloat(r.SearchSeconds, 'f', 7, 64),
+		}
+		row = append(row, r.statsResult.columns()...)
+		row = append(row, strconv.Itoa(r.Workers))
+
+		workers := make([][]string, len(r.WorkerStats))
+		for i := range r.WorkerStats {
+			workers[i] = r.WorkerStats[i].columns()
+		}
+		for column := range statsHeader {
+			values := make([]string, len(workers))
+			for i := range workers {
+				values[i] = workers[i][column]
+			}
+
+			row = append(row, strings.Join(values, " "))
+		}
+
+		writeCSV(row)
+	}
+}
+
+// writeCSV writes the given row separated by semicolons, as bench.csv is, to STDOUT.
+func writeCSV(row []string) {
+	w := csv.NewWriter(os.Stdout)
+	w.Comma = ';'
+	// Writing to STDOUT can only fail if STDOUT is gone.
+	_ = w.Write(row)
+	w.Flush()
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// StartProfiles starts the profiles which have been requested by the options. The returned function stops the profiles and writes the remaining ones to their files, it must be called once the work to profile is done.
+// Run profiles the search on its own, this is only needed for work outside of Run.
+func (o *Options) StartProfiles() (func() error, error) {
+	var stops []func() error
+	stop := func() error {
+		var err error
+		for i := len(stops) - 1; i >= 0; i-- {
+			if e := stops[i](); e != nil && err == nil {
+				err = e
+			}
+		}
+
+		return err
+	}
+
+	if o.CPUProfile != "" {
+		f, err := os.Create(o.CPUProfile)
+		if err != nil {
+			return nil, err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+
+			return nil, err
+		}
+
+		stops = append(stops, func() error {
+			pprof.StopCPUProfile()
+
+			return f.Close()
+		})
+	}
+	if o.Trace != "" {
+		f, err := os.Create(o.Trace)
+		if err != nil {
+			_ = stop()
+
+			return nil, err
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			_ = stop()
+
+			return nil, err
+		}
+
+		stops = append(stops, func() error {
+			trace.Stop()
+
+			return f.Close()
+		})
+	}
+	if o.BlockProfile != "" {
+		// Record every blocking event.
+		runtime.SetBlockProfileRate(1)
+
+		stops = append(stops, func() error {
+			runtime.SetBlockProfileRate(0)
+
+			return writeProfile("block", o.BlockProfile)
+		})
+	}
+	if o.MutexProfile != "" {
+		// Record every contended mutex.
+		runtime.SetMutexProfileFraction(1)
+
+		stops = append(stops, func() error {
+			runtime.SetMutexProfileFraction(0)
+
+			return writeProfile("mutex", o.MutexProfile)
+		})
+	}
+	if o.MemProfile != "" {
+		stops = append(stops, func() error {
+			// Get up-to-date statistics of the live objects.
+			runtime.GC()
+
+			return writeProfile("heap", o.MemProfile)
+		})
+	}
+
+	return stop, nil
+}
+
+// writeProfile writes the profile with the given name to the given file.
+func writeProfile(name string, filepath string) error {
+	f, err := os.Create(filepath)
+	if err != nil {
+		return err
+	}
+
+	if err := pprof.Lookup(name).WriteTo(f, 0); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/zimmski/jku/parallel-computing/assignments/01b/cli"
+	"github.com/zimmski/jku/parallel-computing/assignments/01b/tsp"
+)
+
+func main() {
+	var options cli.Options
+	options.RegisterFlags(flag.CommandLine)
+	listen := flag.String("listen", "", "run as coordinator and accept workers on the given address, e.g. \":7000\"")
+	connect := flag.String("connect", "", "run as worker and connect to the coordinator with the given address, e.g. \"localhost:7000\"")
+	workers := flag.Int("workers", 0, "number of subtrees a worker searches concurrently, 0 uses GOMAXPROCS")
+	splitDepth := flag.Int("split-depth", 0, "number of nodes of the paths the coordinator hands out to the workers, 0 uses 3 nodes")
+	lease := flag.Duration("lease", 0, "time after which the paths of a worker without heartbeat are handed out again, 0 uses 5 seconds")
+	flag.Parse()
+
+	switch {
+	case *connect != "" && *listen == "":
+		if flag.NArg() != 0 {
+			fmt.Println("Worker must be called without arguments.")
+			flag.PrintDefaults()
+
+			os.Exit(1)
+		}
+
+		stopProfiles, err := options.StartProfiles()
+		if err != nil {
+			fmt.Println(err)
+
+			os.Exit(1)
+		}
+
+		// The context is cancelled explicitly since os.Exit does not run deferred calls.
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if options.Timeout > 0 {
+			ctx, cancel = context.WithTimeout(context.Background(), options.Timeout)
+		} else {
+			ctx, cancel = context.WithCancel(context.Background())
+		}
+
+		start := time.Now()
+		err = (&tsp.RemoteWorker{Workers: *workers}).Work(ctx, *connect)
+		cancel()
+		if stopErr := stopProfiles(); err == nil {
+			err = stopErr
+		}
+		if err != nil {
+			fmt.Println(err)
+
+			os.Exit(1)
+		}
+		fmt.Printf("Worker finished after %0.7f seconds\n", time.Since(start).Seconds())
+	case *listen != "" && *connect == "":
+		if flag.NArg() != 1 {
+			fmt.Println("Coordinator must be called with <filepath to graph file> as argument.")
+			flag.PrintDefaults()
+
+			os.Exit(1)
+		}
+		options.GraphFile = flag.Arg(0)
+
+		if err := options.CheckAlgorithm(cli.AlgorithmDFS); err != nil {
+			fmt.Println(err)
+			flag.PrintDefaults()
+
+			os.Exit(1)
+		}
+		if *lease < 0 {
+			fmt.Printf("lease must not be negative but is %s\n", *lease)
+			flag.PrintDefaults()
+
+			os.Exit(1)
+		}
+
+		warmStart, err := options.WarmStart()
+		if err != nil {
+			fmt.Println(err)
+
+			os.Exit(1)
+		}
+
+		listener, err := net.Listen("tcp", *listen)
+		if err != nil {
+			fmt.Println(err)
+
+			os.Exit(1)
+		}
+		if options.Format == cli.FormatText {
+			fmt.Printf("Waiting for workers on %s\n", listener.Addr())
+		}
+
+		os.Exit(options.Run(&tsp.Coordinator{
+			Listener:   listener,
+			SplitDepth: *splitDepth,
+			Lease:      *lease,
+			Progress:   options.ProgressFunc(),
+			Bound:      tsp.Bound(options.Bound),
+			WarmStart:  warmStart,
+		}))
+	default:
+		fmt.Println("Program must be called with either -listen <address> <filepath to graph file> as coordinator or with -connect <address> as worker.")
+		flag.PrintDefaults()
+
+		os.Exit(1)
+	}
+}
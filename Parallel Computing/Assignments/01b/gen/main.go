@@ -6,11 +6,13 @@ import (
 	"os"
 	"strconv"
 	"time"
+
+	"github.com/zimmski/jku/parallel-computing/assignments/01b/tsp"
 )
 
 func main() {
-	if len(os.Args) != 3 {
-		fmt.Println("Program must be called with <number of nodes> <fraction of the matrix that is not zero in percent> as arguments.")
+	if len(os.Args) != 3 && len(os.Args) != 4 {
+		fmt.Println("Program must be called with <number of nodes> <fraction of the matrix that is not zero in percent> [<minimum length of an edge>] as arguments.")
 
 		os.Exit(1)
 	}
@@ -38,41 +40,48 @@ func main() {
 
 		os.Exit(1)
 	}
+	// The minimum length for an edge. Edges of length zero need absent-edge markers in the graph file, which the C programs cannot read.
+	minLength := 1
+	if len(os.Args) == 4 {
+		minLength, err = strconv.Atoi(os.Args[3])
+		if err != nil {
+			fmt.Println("Minimum length argument is not a number")
 
-	// Initialize the matrix.
-	a := make([][]int, numberOfNodes)
-	for y := 0; y < len(a); y++ {
-		a[y] = make([]int, numberOfNodes)
+			os.Exit(1)
+		}
+		if minLength < 0 || minLength > 100 {
+			fmt.Println("Minimum length must be between 0 and 100")
+
+			os.Exit(1)
+		}
 	}
 
+	// Initialize the matrix.
+	g := tsp.NewGraph(numberOfNodes)
+
 	// Our random generator which is initialized with the OS's time in nanoseconds.
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 	// The maximum length for an edge.
 	maxLength := 100
 
-	// Set a fraction of the matrix to none-zero lengths excluding the edges that point to the same node.
+	// Add edges to a fraction of the matrix excluding the edges that point to the same node.
 	for i := 0; i < ((numberOfNodes*numberOfNodes)-numberOfNodes)*fraction/100; i++ {
 		var x, y int
 
 		// Find an edge in the matrix which is not an edge to the same not and is unused.
-		for x == y || a[y][x] != 0 {
+		for x == y || g.HasEdge(y, x) {
 			e := r.Intn(numberOfNodes * numberOfNodes)
 			y = e / numberOfNodes
 			x = e % numberOfNodes
 		}
 
-		a[y][x] = r.Intn(maxLength) + 1
+		g.SetEdge(y, x, r.Intn(maxLength-minLength+1)+minLength)
 	}
 
 	// Print out the matrix.
-	fmt.Println(numberOfNodes)
-	for y := 0; y < numberOfNodes; y++ {
-		for x := 0; x < numberOfNodes; x++ {
-			fmt.Print(a[y][x])
-			if x != numberOfNodes-1 {
-				fmt.Print("\t")
-			}
-		}
-		fmt.Println()
+	if err := g.Write(os.Stdout); err != nil {
+		fmt.Println(err)
+
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,5 @@
+module github.com/zimmski/jku/parallel-computing/assignments/01b
+
+go 1.13
+
+require github.com/stretchr/testify v1.9.0
@@ -1,3 +1,5 @@
+// +build ignore
+
 #include <omp.h>
 #include <stdbool.h>
 #include <stdio.h>
@@ -0,0 +1,194 @@
+package tsp
+
+import (
+	"time"
+)
+
+// maxBitPathNodes holds the maximum number of nodes of a graph whose paths can be held by a bitPath.
+const maxBitPathNodes = 64
+
+// bitPath holds a path through a graph with at most maxBitPathNodes nodes. The visited nodes are a bitset and the order is a fixed array, so a bitPath is a plain value which is copied without following slices.
+type bitPath struct {
+	Length      int
+	Visited     uint64
+	OrderLength int
+	Order       [maxBitPathNodes]uint8
+}
+
+// pathExists takes the given node index and returns if the node can be added to the path. It behaves like Path.PathExists.
+func (p *bitPath) pathExists(g *Graph, node int) bool {
+	// If the path is empty, we can add the node right away.
+	if p.OrderLength == 0 {
+		return true
+	}
+
+	// If there is no edge, there is no path.
+	if !g.HasEdge(int(p.Order[p.OrderLength-1]), node) {
+		return false
+	}
+
+	if p.Visited&(1<<uint(node)) != 0 {
+		// Exit if we do not have all nodes in our path or if the start node is not equal to the end node.
+		if p.OrderLength != g.NumberOfNodes || int(p.Order[0]) != node {
+			return false
+		}
+	}
+
+	return true
+}
+
+// addNode takes the given node index and adds the node to the path. It behaves like Path.AddNode.
+func (p *bitPath) addNode(g *Graph, node int) {
+	if p.OrderLength == 0 {
+		p.Visited = 1 << uint(node)
+		p.Order[0] = uint8(node)
+		p.OrderLength = 1
+
+		return
+	}
+
+	p.Length += g.Matrix[p.Order[p.OrderLength-1]][node]
+
+	// Do not record the last edge.
+	if p.Visited&(1<<uint(node)) == 0 {
+		p.Visited |= 1 << uint(node)
+		p.Order[p.OrderLength] = uint8(node)
+	}
+	p.OrderLength++
+}
+
+// removeLastNode removes the last inserted node. It behaves like Path.RemoveLastNode.
+func (p *bitPath) removeLastNode(g *Graph) {
+	if p.OrderLength == 0 {
+		return
+	}
+
+	node := p.Order[p.OrderLength-1]
+
+	p.Visited &^= 1 << uint(node)
+	p.Length -= g.Matrix[p.Order[p.OrderLength-2]][node]
+	p.OrderLength--
+}
+
+// copyTo copies the path into the given path of the same graph.
+func (p *bitPath) copyTo(to *Path) {
+	to.Length = p.Length
+	for i := range to.Visited {
+		to.Visited[i] = p.Visited&(1<<uint(i)) != 0
+		to.Order[i] = 0
+	}
+	orderLength := p.OrderLength
+	if orderLength > len(to.Order) {
+		orderLength = len(to.Order)
+	}
+	for i := 0; i < orderLength; i++ {
+		to.Order[i] = int(p.Order[i])
+	}
+	to.OrderLength = p.OrderLength
+}
+
+// newBitPath returns the given path as bitPath. The graph of the path must not have more than maxBitPathNodes nodes.
+func newBitPath(from *Path) bitPath {
+	var p bitPath
+
+	p.Length = from.Length
+	for i, visited := range from.Visited {
+		if visited {
+			p.Visited |= 1 << uint(i)
+		}
+	}
+	for i := 0; i < from.OrderLength && i < len(from.Order); i++ {
+		p.Order[i] = uint8(from.Order[i])
+	}
+	p.OrderLength = from.OrderLength
+
+	return p
+}
+
+// solveBitsetFrom does the same search as solveFrom but holds the paths of the stack as bitPath values. The graph of the search must not have more than maxBitPathNodes nodes.
+func (s *sequentialSearch) solveBitsetFrom(root *Path) *Path {
+	g := s.graph
+
+	// Preallocate the stack, its paths are values so there is nothing else to allocate.
+	stack := make([]bitPath, maxStackPaths(g.NumberOfNodes))
+	stackLength := 0
+
+	_, unbounded := s.bound.(noneBound)
+	s.searchStart = time.Now()
+
+	// Init the stack by adding the first path.
+	stack[0] = newBitPath(root)
+	stackLength++
+
+	winner := NewPath(g)
+	if s.initial != nil {
+		CopyPath(s.initial, winner)
+		s.reportProgress(winner)
+	}
+
+	var p bitPath
+	for stackLength != 0 {
+		// Stop with the best path found so far if the search was cancelled.
+		if s.stop.isSet() {
+			break
+		}
+
+		stackLength--
+		p = stack[stackLength]
+		s.stats.Expanded++
+
+		bestLength := s.bestLength(winner)
+
+		for _, i := range s.out[p.Order[p.OrderLength-1]] {
+			if !p.pathExists(g, i) {
+				continue
+			}
+
+			p.addNode(g, i)
+
+			// If the path is at the last node
+			if p.OrderLength == g.NumberOfNodes {
+				// We know that the last edge of a path is always the same node, so do this last step right now and therefore drop all other paths for this node.
+
+				if p.pathExists(g, int(p.Order[0])) {
+					p.addNode(g, int(p.Order[0]))
+					s.stats.Completed++
+
+					// Record if the current path is the best one.
+					if bestLength == noTour || p.Length < bestLength {
+						s.stats.Improved++
+						bestLength = p.Length
+						p.copyTo(winner)
+						s.reportProgress(winner)
+					}
+				}
+
+				break
+			}
+
+			// If the path is not done, put the path back on the stack but only proceed with paths that can still be shorter than the current best path.
+			var isPromising bool
+			if unbounded {
+				isPromising = bestLength == noTour || p.Length < bestLength
+			} else {
+				isPromising = promisingBits(s.bound, &p, bestLength)
+			}
+			if isPromising {
+				stack[stackLength] = p
+				stackLength++
+				s.stats.Pushed++
+			} else {
+				s.stats.Pruned++
+			}
+
+			p.removeLastNode(g)
+		}
+	}
+
+	if winner.OrderLength == 0 {
+		// There is no winner
+		return nil
+	}
+
+	return winner
+}
@@ -0,0 +1,146 @@
+package tsp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitPath(t *testing.T) {
+	g, err := ReadGraph("../graphs/01-original.graph")
+	assert.NoError(t, err)
+
+	var b bitPath
+	p := NewPath(g)
+
+	// Every step must leave both representations with the same path.
+	for _, node := range []int{0, 1, 3, 2, 0} {
+		assert.Equal(t, p.PathExists(g, node), b.pathExists(g, node))
+
+		p.AddNode(g, node)
+		b.addNode(g, node)
+
+		copied := NewPath(g)
+		b.copyTo(copied)
+		assert.Equal(t, p, copied)
+	}
+	assert.Equal(t, 20, b.Length)
+	assert.Equal(t, 5, b.OrderLength)
+
+	// Node 0 cannot be added to an incomplete path again.
+	b = bitPath{}
+	b.addNode(g, 0)
+	b.addNode(g, 1)
+	b.addNode(g, 3)
+	assert.False(t, b.pathExists(g, 0))
+	assert.False(t, b.pathExists(g, 1))
+
+	b.removeLastNode(g)
+	assert.Equal(t, 1, b.Length)
+	assert.Equal(t, uint64(1<<0|1<<1), b.Visited)
+	assert.Equal(t, 2, b.OrderLength)
+	assert.True(t, b.pathExists(g, 3))
+
+	// A path converted from and to a Path is the same path.
+	p = NewPath(g)
+	p.AddNode(g, 0)
+	p.AddNode(g, 2)
+	b = newBitPath(p)
+	copied := NewPath(g)
+	b.copyTo(copied)
+	assert.Equal(t, p, copied)
+}
+
+func TestSolveBitsetBounds(t *testing.T) {
+	g, err := ReadGraph("../graphs/08-18-nodes-fraction-90.graph")
+	assert.NoError(t, err)
+
+	expected, err := (&Sequential{Bound: BoundReduced}).Solve(context.Background(), g)
+	assert.NoError(t, err)
+
+	for _, bound := range []Bound{BoundMinInOut, BoundReduced} {
+		t.Run(string(bound), func(t *testing.T) {
+			tour, err := (&Sequential{Bound: bound, Bitset: true}).Solve(context.Background(), g)
+			assert.NoError(t, err)
+
+			assert.Equal(t, expected.Length, tour.Length)
+			assert.True(t, tour.Optimal)
+		})
+	}
+}
+
+// benchmarkPath returns a path of the given graph which visits half of its nodes.
+func benchmarkPath(g *Graph) *Path {
+	p := NewPath(g)
+	for i := 0; i < g.NumberOfNodes/2; i++ {
+		p.AddNode(g, i)
+	}
+
+	return p
+}
+
+func BenchmarkCopyPath(b *testing.B) {
+	g := randomGraph(1, 20, 100)
+	from := benchmarkPath(g)
+	stack := make([]*Path, 16)
+	for i := range stack {
+		stack[i] = NewPath(g)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		CopyPath(from, stack[i%len(stack)])
+	}
+}
+
+// bitPathSink keeps the compiler from dropping the copies of BenchmarkCopyBitPath.
+var bitPathSink []bitPath
+
+func BenchmarkCopyBitPath(b *testing.B) {
+	g := randomGraph(1, 20, 100)
+	from := newBitPath(benchmarkPath(g))
+	stack := make([]bitPath, 16)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		stack[i%len(stack)] = from
+	}
+	bitPathSink = stack
+}
+
+// benchmarkSolver solves the same graph with the given solver as often as the benchmark needs.
+func benchmarkSolver(b *testing.B, s Solver) {
+	g := randomGraph(1, 10, 100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := s.Solve(context.Background(), g); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSequential(b *testing.B) {
+	benchmarkSolver(b, &Sequential{})
+}
+
+func BenchmarkSequentialBitset(b *testing.B) {
+	benchmarkSolver(b, &Sequential{Bitset: true})
+}
+
+func BenchmarkSequentialUndo(b *testing.B) {
+	benchmarkSolver(b, &Sequential{Undo: true})
+}
+
+func BenchmarkSequentialReduced(b *testing.B) {
+	benchmarkSolver(b, &Sequential{Bound: BoundReduced})
+}
+
+func BenchmarkSequentialReducedBitset(b *testing.B) {
+	benchmarkSolver(b, &Sequential{Bound: BoundReduced, Bitset: true})
+}
+
+func BenchmarkSequentialReducedUndo(b *testing.B) {
+	benchmarkSolver(b, &Sequential{Bound: BoundReduced, Undo: true})
+}
@@ -0,0 +1,287 @@
+package tsp
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Bound names an admissible lower bound for the length which is still needed to complete a path to a cyclic path.
+// The search drops every path whose length plus its lower bound is not shorter than the current best cyclic path.
+type Bound string
+
+const (
+	// BoundNone does not estimate the remaining length, paths are only pruned by their own length.
+	BoundNone Bound = "none"
+	// BoundMinOut sums up the shortest outgoing edge of the last node and of every unvisited node, since each of these nodes must still be left once.
+	BoundMinOut Bound = "minout"
+	// BoundMinInOut takes the maximum of BoundMinOut and the sum of the shortest incoming edge of every unvisited node and of the start node, since each of these nodes must still be entered once.
+	BoundMinInOut Bound = "mininout"
+	// BoundReduced reduces the cost matrix of the remaining edges row- and column-wise as done by Little's algorithm and sums up the reductions.
+	BoundReduced Bound = "reduced"
+)
+
+// Bounds holds all available bounds.
+var Bounds = []Bound{BoundNone, BoundMinOut, BoundMinInOut, BoundReduced}
+
+// noCompletion is returned by a bounder if a path cannot be completed to a cyclic path at all.
+const noCompletion = -1
+
+// bounder computes the lower bound for paths of one graph. A bounder may hold scratch space and must therefore not be shared between goroutines.
+type bounder interface {
+	// lowerBound returns the lower bound for the length which is still needed to complete the given path to a cyclic path, or noCompletion if there is no completion.
+	lowerBound(p *Path) int
+	// lowerBoundBits does the same as lowerBound for the given bitPath, so the bitset search does not have to convert its paths.
+	lowerBoundBits(p *bitPath) int
+}
+
+// newBounder returns a bounder of the bound for the given graph.
+func (b Bound) newBounder(g *Graph) (bounder, error) {
+	switch b {
+	case "", BoundNone:
+		return noneBound{}, nil
+	case BoundMinOut:
+		return newMinEdgeBound(g, false), nil
+	case BoundMinInOut:
+		return newMinEdgeBound(g, true), nil
+	case BoundReduced:
+		return newReducedBound(g), nil
+	}
+
+	return nil, fmt.Errorf("unknown bound %q", string(b))
+}
+
+// promising returns if the given path can still lead to a cyclic path which is shorter than a cyclic path with the given length. A length of noTour means that there is no cyclic path yet.
+func promising(b bounder, p *Path, winnerLength int) bool {
+	lowerBound := b.lowerBound(p)
+	if lowerBound == noCompletion {
+		return false
+	}
+
+	return winnerLength == noTour || p.Length+lowerBound < winnerLength
+}
+
+// promisingBits does the same as promising for the given bitPath.
+func promisingBits(b bounder, p *bitPath, winnerLength int) bool {
+	lowerBound := b.lowerBoundBits(p)
+	if lowerBound == noCompletion {
+		return false
+	}
+
+	return winnerLength == noTour || p.Length+lowerBound < winnerLength
+}
+
+// unvisitedBits returns the bitset of the nodes of the given graph which are not visited by the given bitPath.
+func unvisitedBits(g *Graph, p *bitPath) uint64 {
+	return ^p.Visited & (^uint64(0) >> uint(maxBitPathNodes-g.NumberOfNodes))
+}
+
+type noneBound struct{}
+
+func (noneBound) lowerBound(p *Path) int {
+	return 0
+}
+
+func (noneBound) lowerBoundBits(p *bitPath) int {
+	return 0
+}
+
+type minEdgeBound struct {
+	graph    *Graph
+	minOut   []int
+	minIn    []int
+	useMinIn bool
+}
+
+func newMinEdgeBound(g *Graph, useMinIn bool) *minEdgeBound {
+	b := &minEdgeBound{
+		graph:    g,
+		minOut:   make([]int, g.NumberOfNodes),
+		minIn:    make([]int, g.NumberOfNodes),
+		useMinIn: useMinIn,
+	}
+
+	for y := 0; y < g.NumberOfNodes; y++ {
+		b.minOut[y] = noCompletion
+		b.minIn[y] = noCompletion
+	}
+	for y := 0; y < g.NumberOfNodes; y++ {
+		for x := 0; x < g.NumberOfNodes; x++ {
+			if x == y || !g.HasEdge(y, x) {
+				continue
+			}
+
+			if b.minOut[y] == noCompletion || g.Matrix[y][x] < b.minOut[y] {
+				b.minOut[y] = g.Matrix[y][x]
+			}
+			if b.minIn[x] == noCompletion || g.Matrix[y][x] < b.minIn[x] {
+				b.minIn[x] = g.Matrix[y][x]
+			}
+		}
+	}
+
+	return b
+}
+
+func (b *minEdgeBound) lowerBound(p *Path) int {
+	// The last node of the path must still be left and the start node must still be entered.
+	out := b.minOut[p.Order[p.OrderLength-1]]
+	if out == noCompletion {
+		return noCompletion
+	}
+	in := b.minIn[p.Order[0]]
+	if in == noCompletion {
+		return noCompletion
+	}
+
+	// Every unvisited node must still be entered and left.
+	for node := 0; node < b.graph.NumberOfNodes; node++ {
+		if p.Visited[node] {
+			continue
+		}
+
+		if b.minOut[node] == noCompletion || b.minIn[node] == noCompletion {
+			return noCompletion
+		}
+
+		out += b.minOut[node]
+		in += b.minIn[node]
+	}
+
+	if b.useMinIn && in > out {
+		return in
+	}
+
+	return out
+}
+
+func (b *minEdgeBound) lowerBoundBits(p *bitPath) int {
+	// The last node of the path must still be left and the start node must still be entered.
+	out := b.minOut[p.Order[p.OrderLength-1]]
+	if out == noCompletion {
+		return noCompletion
+	}
+	in := b.minIn[p.Order[0]]
+	if in == noCompletion {
+		return noCompletion
+	}
+
+	// Every unvisited node must still be entered and left.
+	for unvisited := unvisitedBits(b.graph, p); unvisited != 0; unvisited &= unvisited - 1 {
+		node := bits.TrailingZeros64(unvisited)
+
+		if b.minOut[node] == noCompletion || b.minIn[node] == noCompletion {
+			return noCompletion
+		}
+
+		out += b.minOut[node]
+		in += b.minIn[node]
+	}
+
+	if b.useMinIn && in > out {
+		return in
+	}
+
+	return out
+}
+
+type reducedBound struct {
+	graph *Graph
+	// rows holds the nodes which must still be left.
+	rows []int
+	// columns holds the nodes which must still be entered.
+	columns []int
+	rowMin  []int
+}
+
+func newReducedBound(g *Graph) *reducedBound {
+	return &reducedBound{
+		graph:   g,
+		rows:    make([]int, 0, g.NumberOfNodes),
+		columns: make([]int, 0, g.NumberOfNodes),
+		rowMin:  make([]int, g.NumberOfNodes),
+	}
+}
+
+func (b *reducedBound) lowerBound(p *Path) int {
+	last := p.Order[p.OrderLength-1]
+
+	b.rows = append(b.rows[:0], last)
+	b.columns = b.columns[:0]
+	for node := 0; node < b.graph.NumberOfNodes; node++ {
+		if !p.Visited[node] {
+			b.rows = append(b.rows, node)
+			b.columns = append(b.columns, node)
+		}
+	}
+
+	return b.reduce(last, p.Order[0])
+}
+
+func (b *reducedBound) lowerBoundBits(p *bitPath) int {
+	last := int(p.Order[p.OrderLength-1])
+
+	b.rows = append(b.rows[:0], last)
+	b.columns = b.columns[:0]
+	for unvisited := unvisitedBits(b.graph, p); unvisited != 0; unvisited &= unvisited - 1 {
+		node := bits.TrailingZeros64(unvisited)
+
+		b.rows = append(b.rows, node)
+		b.columns = append(b.columns, node)
+	}
+
+	return b.reduce(last, int(p.Order[0]))
+}
+
+// reduce returns the lower bound for a path from the given last node back to the given start node over the unvisited nodes, which must already be in the rows after the last node and in the columns.
+func (b *reducedBound) reduce(last int, start int) int {
+	g := b.graph
+
+	b.columns = append(b.columns, start)
+
+	// allowed returns if the remaining cyclic path can use the edge from the given node to the other given node.
+	allowed := func(from int, to int) bool {
+		if from == to || !g.HasEdge(from, to) {
+			return false
+		}
+		// The last node can only return to the start node if there are no unvisited nodes.
+		if from == last && to == start && len(b.rows) > 1 {
+			return false
+		}
+
+		return true
+	}
+
+	lowerBound := 0
+
+	// Reduce every row by its minimum.
+	for i, from := range b.rows {
+		b.rowMin[i] = noCompletion
+		for _, to := range b.columns {
+			if allowed(from, to) && (b.rowMin[i] == noCompletion || g.Matrix[from][to] < b.rowMin[i]) {
+				b.rowMin[i] = g.Matrix[from][to]
+			}
+		}
+		if b.rowMin[i] == noCompletion {
+			return noCompletion
+		}
+
+		lowerBound += b.rowMin[i]
+	}
+
+	// Reduce every column of the reduced rows by its minimum.
+	for _, to := range b.columns {
+		columnMin := noCompletion
+		for i, from := range b.rows {
+			if allowed(from, to) && (columnMin == noCompletion || g.Matrix[from][to]-b.rowMin[i] < columnMin) {
+				columnMin = g.Matrix[from][to] - b.rowMin[i]
+			}
+		}
+		if columnMin == noCompletion {
+			return noCompletion
+		}
+
+		lowerBound += columnMin
+	}
+
+	return lowerBound
+}
@@ -0,0 +1,111 @@
+package tsp
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolveBounds(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		g := randomGraph(seed, 9, 50)
+
+		expected, err := (&Sequential{}).Solve(context.Background(), g)
+		assert.NoError(t, err)
+		if expected.Order == nil {
+			continue
+		}
+
+		for _, b := range Bounds {
+			for name, s := range map[string]Solver{
+				"Sequential": &Sequential{Bound: b},
+				"Parallel":   &Parallel{Bound: b, Workers: 4},
+			} {
+				t.Run(fmt.Sprintf("%s-%s-%d", name, b, seed), func(t *testing.T) {
+					tour, err := s.Solve(context.Background(), g)
+					assert.NoError(t, err)
+
+					assert.Equal(t, expected.Length, tour.Length)
+					assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+					// The order in which parallel workers find tours varies, so only the sequential search expands comparable numbers of paths.
+					if _, ok := s.(*Sequential); ok {
+						assert.True(t, tour.Stats.Expanded <= expected.Stats.Expanded)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestSolveBoundsGraphs(t *testing.T) {
+	for _, tc := range []struct {
+		file   string
+		length int
+	}{
+		{"../graphs/02-20-nodes-fraction-65.graph", 352},
+		{"../graphs/05-25-nodes-fraction-35.graph", 457},
+		{"../graphs/08-18-nodes-fraction-90.graph", 209},
+	} {
+		g, err := ReadGraph(tc.file)
+		assert.NoError(t, err)
+
+		for name, s := range map[string]Solver{
+			"Sequential": &Sequential{Bound: BoundReduced},
+			"Parallel":   &Parallel{Bound: BoundReduced, Workers: 4},
+		} {
+			t.Run(name+"-"+tc.file, func(t *testing.T) {
+				tour, err := s.Solve(context.Background(), g)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tc.length, tour.Length)
+				assert.True(t, tour.Optimal)
+			})
+		}
+	}
+}
+
+func TestLowerBoundBits(t *testing.T) {
+	for _, numberOfNodes := range []int{5, 17, maxBitPathNodes} {
+		g := randomGraph(int64(numberOfNodes), numberOfNodes, 70)
+		out := g.outgoing()
+
+		for _, b := range Bounds {
+			bound, err := b.newBounder(g)
+			assert.NoError(t, err)
+
+			// Every prefix of a path along the first unvisited neighbours must have the same bound in both representations.
+			p := NewPath(g)
+			p.AddNode(g, 0)
+			for {
+				bits := newBitPath(p)
+				assert.Equal(t, bound.lowerBound(p), bound.lowerBoundBits(&bits), "%s bound of %v with %d nodes", b, p.Order[:p.OrderLength], numberOfNodes)
+
+				next := -1
+				for _, node := range out[p.Order[p.OrderLength-1]] {
+					if !p.Visited[node] {
+						next = node
+
+						break
+					}
+				}
+				if next == -1 {
+					break
+				}
+				p.AddNode(g, next)
+			}
+		}
+	}
+}
+
+func TestUnknownBound(t *testing.T) {
+	g, err := ReadGraph("../graphs/01-original.graph")
+	assert.NoError(t, err)
+
+	_, err = (&Sequential{Bound: "unknown"}).Solve(context.Background(), g)
+	assert.EqualError(t, err, `unknown bound "unknown"`)
+
+	_, err = (&Parallel{Bound: "unknown"}).Solve(context.Background(), g)
+	assert.EqualError(t, err, `unknown bound "unknown"`)
+}
@@ -0,0 +1,191 @@
+package tsp
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Checkpoint holds the state of a parallel search from which the search can be resumed. Since every path which has not been searched yet is part of the checkpoint, a resumed search finds the same optimum as an uninterrupted one.
+type Checkpoint struct {
+	// Graph holds the fingerprint of the graph of the search.
+	Graph string
+	// Winner holds the nodes of the best cyclic path found so far beginning with the start node, it is nil if there is none.
+	Winner []int
+	// Queue holds the nodes of the paths of the shared queue.
+	Queue [][]int
+	// Stacks holds the nodes of the paths of the stack of every worker.
+	Stacks [][][]int
+	// Stats holds the statistics of the search so far.
+	Stats Stats
+}
+
+// ReadCheckpoint reads in a checkpoint from the given file.
+func ReadCheckpoint(filepath string) (*Checkpoint, error) {
+	data, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Checkpoint
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint file %q: %v", filepath, err)
+	}
+
+	return &c, nil
+}
+
+// Write writes the checkpoint to the given file. The file is replaced at once, so an interrupted write does not destroy an earlier checkpoint.
+func (c *Checkpoint) Write(filepath string) error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	temporary := filepath + ".tmp"
+	if err := ioutil.WriteFile(temporary, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(temporary, filepath)
+}
+
+// graphFingerprint returns a fingerprint of the given graph to recognize checkpoints of other graphs.
+func graphFingerprint(g *Graph) string {
+	h := fnv.New64a()
+	for y, row := range g.Matrix {
+		for x, length := range row {
+			if g.HasEdge(y, x) {
+				fmt.Fprintf(h, "%d,", length)
+			} else {
+				fmt.Fprint(h, "-,")
+			}
+		}
+	}
+
+	return fmt.Sprintf("%d:%x", g.NumberOfNodes, h.Sum64())
+}
+
+// writeCheckpoints writes a checkpoint after every checkpoint interval until the given channel is closed.
+func (s *parallelSearch) writeCheckpoints(finished <-chan struct{}) error {
+	ticker := time.NewTicker(s.checkpointInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-finished:
+			return nil
+		case <-ticker.C:
+		}
+
+		// Wait until all workers are paused at a safe point.
+		atomic.StoreInt32(&s.pauseRequested, 1)
+		s.pause.Lock()
+		atomic.StoreInt32(&s.pauseRequested, 0)
+
+		err := s.checkpoint().Write(s.checkpointFile)
+
+		s.pause.Unlock()
+
+		if err != nil {
+			s.stop.set()
+
+			return err
+		}
+	}
+}
+
+// checkpoint returns the current state of the search. No worker must be running.
+func (s *parallelSearch) checkpoint() *Checkpoint {
+	c := &Checkpoint{
+		Graph:  graphFingerprint(s.graph),
+		Queue:  [][]int{},
+		Stacks: make([][][]int, len(s.workers)),
+	}
+
+	if winner := s.winner.Path(); winner != nil {
+		c.Winner = newTour(winner, false).Order
+	}
+
+	s.queue.Lock()
+	for i := 0; i < s.queue.Length; i++ {
+		c.Queue = append(c.Queue, pathOrder(s.queue.Items[(s.queue.Current+i)%len(s.queue.Items)]))
+	}
+	s.queue.Unlock()
+
+	for i, w := range s.workers {
+		w.Stack.Lock()
+		c.Stacks[i] = [][]int{}
+		for j := w.Stack.Bottom; j < w.Stack.Length; j++ {
+			c.Stacks[i] = append(c.Stacks[i], pathOrder(w.Stack.Items[j]))
+		}
+		w.Stack.Unlock()
+	}
+	c.Stats = s.stats()
+
+	return c
+}
+
+// restoreCheckpoint restores the winner, the queue and the stacks of the given checkpoint. If the number of workers has changed, the paths of all stacks are put into the queue.
+func (s *parallelSearch) restoreCheckpoint(c *Checkpoint) error {
+	g := s.graph
+
+	if c.Winner != nil {
+		winner, err := PathFromOrder(g, append(c.Winner, c.Winner[0]))
+		if err != nil {
+			return fmt.Errorf("invalid winner in checkpoint: %v", err)
+		} else if winner.OrderLength != g.NumberOfNodes+1 {
+			return fmt.Errorf("winner %v of checkpoint does not visit all nodes", c.Winner)
+		}
+
+		s.winner.improve(g, winner)
+	}
+
+	queue := c.Queue
+	if len(c.Stacks) == len(s.workers) {
+		for i, stack := range c.Stacks {
+			if len(stack) > len(s.workers[i].Stack.Items) {
+				return fmt.Errorf("stack %d of checkpoint has %d paths but a stack can only hold %d", i, len(stack), len(s.workers[i].Stack.Items))
+			}
+
+			for _, order := range stack {
+				p, err := PathFromOrder(g, order)
+				if err != nil {
+					return fmt.Errorf("invalid path in checkpoint: %v", err)
+				}
+
+				s.workers[i].Stack.pushStack(p)
+			}
+		}
+	} else {
+		for _, stack := range c.Stacks {
+			queue = append(queue, stack...)
+		}
+	}
+
+	// The queue must be able to take the restored paths and the expansion of one of them.
+	if s.queue.Capacity < len(queue)+g.NumberOfNodes {
+		s.queue.Capacity = len(queue) + g.NumberOfNodes
+	}
+	for _, order := range queue {
+		p, err := PathFromOrder(g, order)
+		if err != nil {
+			return fmt.Errorf("invalid path in checkpoint: %v", err)
+		}
+
+		if err := s.queue.addQueue(p); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pathOrder returns a copy of the nodes of the given path.
+func pathOrder(p *Path) []int {
+	return append([]int(nil), p.Order[:p.OrderLength]...)
+}
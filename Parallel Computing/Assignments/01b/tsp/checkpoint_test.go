@@ -0,0 +1,89 @@
+package tsp
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	directory, err := ioutil.TempDir("", "checkpoint")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+
+	for seed := int64(0); seed < 5; seed++ {
+		g := randomGraph(seed, 11, 70)
+
+		expected, err := (&Sequential{}).Solve(context.Background(), g)
+		assert.NoError(t, err)
+
+		for _, workers := range [][2]int{{4, 4}, {4, 2}, {1, 3}} {
+			t.Run(fmt.Sprintf("%d-%d-%d", seed, workers[0], workers[1]), func(t *testing.T) {
+				file := filepath.Join(directory, fmt.Sprintf("%d-%d-%d", seed, workers[0], workers[1]))
+
+				// Interrupt the search with its first improved tour.
+				ctx, cancel := context.WithCancel(context.Background())
+				tour, err := (&Parallel{
+					Workers:            workers[0],
+					CheckpointFile:     file,
+					CheckpointInterval: time.Millisecond,
+					Progress: func(p Progress) {
+						cancel()
+					},
+				}).Solve(ctx, g)
+				cancel()
+				assert.NoError(t, err)
+
+				checkpoint, err := ReadCheckpoint(file)
+				assert.NoError(t, err)
+				if tour.Order != nil {
+					assert.Equal(t, tour.Order, checkpoint.Winner)
+				}
+
+				tour, err = (&Parallel{
+					Workers:            workers[1],
+					CheckpointFile:     file,
+					CheckpointInterval: time.Millisecond,
+					Resume:             checkpoint,
+				}).Solve(context.Background(), g)
+				assert.NoError(t, err)
+
+				assert.True(t, tour.Optimal)
+				if expected.Order == nil {
+					assert.Nil(t, tour.Order)
+				} else {
+					assert.Equal(t, expected.Length, tour.Length)
+					assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+				}
+
+				// The final checkpoint of a finished search has no paths left.
+				checkpoint, err = ReadCheckpoint(file)
+				assert.NoError(t, err)
+				assert.Empty(t, checkpoint.Queue)
+				for _, stack := range checkpoint.Stacks {
+					assert.Empty(t, stack)
+				}
+			})
+		}
+	}
+}
+
+func TestCheckpointOtherGraph(t *testing.T) {
+	checkpoint := &Checkpoint{
+		Graph: graphFingerprint(randomGraph(1, 5, 100)),
+	}
+
+	_, err := (&Parallel{Resume: checkpoint}).Solve(context.Background(), randomGraph(2, 5, 100))
+	assert.EqualError(t, err, "checkpoint has been written for a different graph")
+}
+
+func TestCheckpointNegativeInterval(t *testing.T) {
+	_, err := (&Parallel{CheckpointFile: "never-written.checkpoint", CheckpointInterval: -time.Second}).Solve(context.Background(), randomGraph(1, 5, 100))
+	assert.EqualError(t, err, "checkpoint interval must not be negative but is -1s")
+}
@@ -0,0 +1,825 @@
+package tsp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/rpc"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// defaultCoordinatorSplitDepth holds the number of nodes of the paths which are handed out to workers if no split depth is given.
+	defaultCoordinatorSplitDepth = 3
+	// defaultCoordinatorLease holds the time after which the subtrees of a silent worker are handed out again if no lease is given.
+	defaultCoordinatorLease = 5 * time.Second
+)
+
+// Coordinator solves graphs by expanding the first levels of the search tree and handing out the remaining subtrees to worker processes which connect over TCP, see RemoteWorker.
+// Improved tours of a worker are broadcast to all workers to prune their subtrees, and the subtrees of a worker which stopped sending heartbeats are handed out again.
+type Coordinator struct {
+	// Listener accepts the connections of the workers. It is closed when Solve returns, so a listener can only be used for one search.
+	Listener net.Listener
+	// SplitDepth holds the number of nodes of the paths which are handed out to the workers. If it is zero, 3 nodes are used.
+	SplitDepth int
+	// Lease holds the time after which the subtrees of a worker which has not sent a heartbeat are handed out again. Workers send heartbeats five times per lease. If it is zero, 5 seconds are used. It must not be negative.
+	Lease time.Duration
+	// Progress is called for every improved tour if it is not nil.
+	Progress ProgressFunc
+	// Bound holds the lower bound which is used to prune paths. If it is empty, BoundNone is used.
+	Bound Bound
+	// WarmStart is used to find an initial tour as the initial bound of the search if it is not nil.
+	WarmStart WarmStart
+}
+
+// JoinArgs holds the arguments of a worker process which joins a coordinator.
+type JoinArgs struct {
+	// Workers holds the number of subtrees the process searches concurrently.
+	Workers int
+}
+
+// JoinReply holds the search a worker process has joined.
+type JoinReply struct {
+	// Process identifies the process in all further calls.
+	Process int
+	// Graph holds the graph of the search.
+	Graph *Graph
+	// Bound holds the lower bound which is used to prune paths.
+	Bound Bound
+	// Heartbeat holds the interval in which the process must send heartbeats.
+	Heartbeat time.Duration
+}
+
+// NextArgs holds the arguments of a worker which asks for its next subtree.
+type NextArgs struct {
+	// Process identifies the process of the worker.
+	Process int
+	// Job holds the subtree the worker has finished, -1 if there is none.
+	Job int
+	// Stats holds the statistics of the finished subtree.
+	Stats Stats
+}
+
+// NextReply holds the next subtree of a worker.
+type NextReply struct {
+	// Job identifies the subtree, it is -1 if there is currently no subtree and the worker should ask again.
+	Job int
+	// Prefix holds the path every cyclic path of the subtree begins with.
+	Prefix []int
+	// Incumbent holds the length of the best cyclic path found so far, -1 means that there is none.
+	Incumbent int
+	// Finished is true if the search is over and the worker should stop.
+	Finished bool
+}
+
+// ImproveArgs holds an improved tour which has been found by a worker.
+type ImproveArgs struct {
+	// Process identifies the process of the worker.
+	Process int
+	// Order holds the nodes of the cyclic path beginning with the start node.
+	Order []int
+}
+
+// ImproveReply holds the state of the search after an improved tour has been reported.
+type ImproveReply struct {
+	// Incumbent holds the length of the best cyclic path found so far.
+	Incumbent int
+}
+
+// HeartbeatArgs holds the heartbeat of a worker process.
+type HeartbeatArgs struct {
+	// Process identifies the process.
+	Process int
+	// Incumbent holds the length of the best cyclic path known by the process, -1 means that there is none. The call returns early as soon as a shorter cyclic path is found.
+	Incumbent int
+}
+
+// HeartbeatReply holds the state of the search for a worker process.
+type HeartbeatReply struct {
+	// Incumbent holds the length of the best cyclic path found so far, -1 means that there is none.
+	Incumbent int
+	// Finished is true if the search is over and the process should stop.
+	Finished bool
+}
+
+// LeaveArgs holds the arguments of a worker process which leaves a coordinator.
+type LeaveArgs struct {
+	// Process identifies the process.
+	Process int
+}
+
+// LeaveReply holds nothing, but net/rpc needs a reply.
+type LeaveReply struct{}
+
+// coordinatorSearch holds the state of one coordinated search. Its exported methods are called by the workers using RPC.
+type coordinatorSearch struct {
+	mu sync.Mutex
+
+	graph     *Graph
+	bound     Bound
+	heartbeat time.Duration
+	lease     time.Duration
+
+	jobs      []coordinatorJob
+	pending   []int
+	remaining int
+	processes []*coordinatorProcess
+
+	bestLength int
+	bestOrder  []int
+	stats      Stats
+
+	// changed is closed and replaced whenever there is a new incumbent, a subtree to hand out or the search is finished.
+	changed  chan struct{}
+	done     chan struct{}
+	finished bool
+
+	progress ProgressFunc
+	start    time.Time
+}
+
+// coordinatorJob holds a subtree of the search.
+type coordinatorJob struct {
+	Prefix []int
+	// Process holds the process which searches the subtree, -1 if the subtree has not been handed out.
+	Process int
+	Done    bool
+}
+
+// coordinatorProcess holds the state of a worker process.
+type coordinatorProcess struct {
+	LastSeen time.Time
+	Dead     bool
+	Left     bool
+	Stats    Stats
+}
+
+// Solve tries to find the shortest cyclic path visiting all nodes in the given graph using the workers which connect to the listener of the coordinator.
+func (c *Coordinator) Solve(ctx context.Context, g *Graph) (*Tour, error) {
+	defer c.Listener.Close()
+
+	// Every search starts at node 0.
+	if g.NumberOfNodes < 1 {
+		return nil, fmt.Errorf("graph must have at least 1 node but has %d", g.NumberOfNodes)
+	}
+
+	splitDepth := c.SplitDepth
+	if splitDepth == 0 {
+		splitDepth = defaultCoordinatorSplitDepth
+	} else if splitDepth < 0 {
+		return nil, fmt.Errorf("split depth must not be negative but is %d", splitDepth)
+	}
+	// Handed out paths must not be complete, since completed paths are only recognized during an expansion.
+	if splitDepth > g.NumberOfNodes-1 {
+		splitDepth = g.NumberOfNodes - 1
+	}
+	lease := c.Lease
+	if lease == 0 {
+		lease = defaultCoordinatorLease
+	} else if lease < 0 {
+		return nil, fmt.Errorf("lease must not be negative but is %s", lease)
+	} else if lease < 5 {
+		// Workers send heartbeats five times per lease, which needs a positive heartbeat interval.
+		return nil, fmt.Errorf("lease must be at least %s but is %s", time.Duration(5), lease)
+	}
+
+	bound, err := c.Bound.newBounder(g)
+	if err != nil {
+		return nil, err
+	}
+
+	initial, err := warmStartPath(g, c.WarmStart)
+	if err != nil {
+		return nil, err
+	}
+
+	search := &coordinatorSearch{
+		graph:      g,
+		bound:      c.Bound,
+		heartbeat:  lease / 5,
+		lease:      lease,
+		changed:    make(chan struct{}),
+		done:       make(chan struct{}),
+		bestLength: noTour,
+		progress:   c.Progress,
+		start:      time.Now(),
+	}
+	if initial != nil {
+		search.bestLength = initial.Length
+		search.bestOrder = newTour(initial, false).Order
+		search.reportProgress()
+	}
+	search.expand(bound, splitDepth)
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("Coordinator", search); err != nil {
+		return nil, err
+	}
+
+	var conns []net.Conn
+	var connsLock sync.Mutex
+	go func() {
+		for {
+			conn, err := c.Listener.Accept()
+			if err != nil {
+				// The listener has been closed.
+				return
+			}
+
+			connsLock.Lock()
+			conns = append(conns, conn)
+			connsLock.Unlock()
+
+			go server.ServeConn(conn)
+		}
+	}()
+	go search.reapProcesses()
+
+	select {
+	case <-search.done:
+	case <-ctx.Done():
+	}
+
+	search.mu.Lock()
+	optimal := search.remaining == 0
+	search.finish()
+
+	tour := &Tour{
+		Optimal: optimal,
+		Stats:   search.stats,
+	}
+	if search.bestOrder != nil {
+		tour.Length = search.bestLength
+		tour.Order = search.bestOrder
+	}
+	tour.Stats.Workers = make([]Stats, len(search.processes))
+	for i, p := range search.processes {
+		tour.Stats.Workers[i] = p.Stats
+		tour.Stats.add(&p.Stats)
+	}
+	search.mu.Unlock()
+
+	// Give the workers up to one lease to notice that the search is finished before they are disconnected.
+	search.waitForProcesses(lease)
+	connsLock.Lock()
+	for _, conn := range conns {
+		conn.Close()
+	}
+	connsLock.Unlock()
+
+	return tour, nil
+}
+
+// expand expands the search tree breadth-first until the paths have the given number of nodes and adds them as jobs.
+func (s *coordinatorSearch) expand(bound bounder, splitDepth int) {
+	g := s.graph
+
+	p := NewPath(g)
+	p.AddNode(g, 0)
+
+	out := g.outgoing()
+
+	frontier := []*Path{p}
+	for depth := 1; depth < splitDepth; depth++ {
+		var next []*Path
+		for _, p := range frontier {
+			s.stats.Expanded++
+
+			for _, i := range out[p.Order[p.OrderLength-1]] {
+				if !p.PathExists(g, i) {
+					continue
+				}
+
+				child := NewPath(g)
+				CopyPath(p, child)
+				child.AddNode(g, i)
+
+				if promising(bound, child, s.bestLength) {
+					next = append(next, child)
+					s.stats.Pushed++
+				} else {
+					s.stats.Pruned++
+				}
+			}
+		}
+		frontier = next
+	}
+
+	// Hand out the paths in the order of the search tree, since pending jobs are taken from the end.
+	s.jobs = make([]coordinatorJob, len(frontier))
+	for i, p := range frontier {
+		s.jobs[i] = coordinatorJob{
+			Prefix:  append([]int(nil), p.Order[:p.OrderLength]...),
+			Process: -1,
+		}
+		s.pending = append(s.pending, len(frontier)-1-i)
+	}
+	s.remaining = len(s.jobs)
+
+	if s.remaining == 0 {
+		s.finish()
+	}
+}
+
+// Join registers a new worker process.
+func (s *coordinatorSearch) Join(args JoinArgs, reply *JoinReply) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.processes = append(s.processes, &coordinatorProcess{
+		LastSeen: time.Now(),
+	})
+
+	reply.Process = len(s.processes) - 1
+	reply.Graph = s.graph
+	reply.Bound = s.bound
+	reply.Heartbeat = s.heartbeat
+
+	return nil
+}
+
+// Next records the finished subtree of a worker and hands out its next subtree. If there is currently no subtree, it waits for one up to the heartbeat interval.
+func (s *coordinatorSearch) Next(args NextArgs, reply *NextReply) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.process(args.Process)
+	if err != nil {
+		return err
+	}
+
+	if args.Job != -1 {
+		if args.Job < 0 || args.Job >= len(s.jobs) {
+			return fmt.Errorf("unknown job %d", args.Job)
+		}
+
+		p.Stats.add(&args.Stats)
+
+		// A subtree can be finished twice if its process was declared dead too early.
+		if job := &s.jobs[args.Job]; !job.Done {
+			job.Done = true
+			s.remaining--
+
+			if s.remaining == 0 {
+				s.finish()
+			}
+		}
+	}
+
+	reply.Job = -1
+	for waited := false; ; waited = true {
+		reply.Incumbent = s.bestLength
+		if s.finished {
+			reply.Finished = true
+
+			return nil
+		} else if len(s.pending) != 0 {
+			id := s.pending[len(s.pending)-1]
+			s.pending = s.pending[:len(s.pending)-1]
+			s.jobs[id].Process = args.Process
+
+			reply.Job = id
+			reply.Prefix = s.jobs[id].Prefix
+
+			return nil
+		} else if waited {
+			return nil
+		}
+
+		s.wait()
+	}
+}
+
+// Improve records an improved tour of a worker and broadcasts its length to all workers.
+func (s *coordinatorSearch) Improve(args ImproveArgs, reply *ImproveReply) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.process(args.Process); err != nil {
+		return err
+	}
+
+	length := tourLength(s.graph, args.Order)
+	if length == -1 {
+		return fmt.Errorf("tour %v is not a cyclic path of the graph", args.Order)
+	}
+
+	if s.bestOrder == nil || length < s.bestLength {
+		s.bestLength = length
+		s.bestOrder = args.Order
+		s.reportProgress()
+		s.notify()
+	}
+
+	reply.Incumbent = s.bestLength
+
+	return nil
+}
+
+// Heartbeat keeps the subtrees of a worker process assigned to it. It returns as soon as there is a shorter cyclic path than the one known by the process, but at the latest after the heartbeat interval.
+func (s *coordinatorSearch) Heartbeat(args HeartbeatArgs, reply *HeartbeatReply) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.process(args.Process); err != nil {
+		return err
+	}
+
+	for waited := false; ; waited = true {
+		reply.Incumbent = s.bestLength
+		reply.Finished = s.finished
+
+		if waited || s.finished || (s.bestLength != noTour && (args.Incumbent == noTour || s.bestLength < args.Incumbent)) {
+			return nil
+		}
+
+		s.wait()
+	}
+}
+
+// Leave unregisters a worker process. Subtrees which it has not finished are handed out again.
+func (s *coordinatorSearch) Leave(args LeaveArgs, reply *LeaveReply) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, err := s.process(args.Process)
+	if err != nil {
+		return err
+	}
+
+	p.Left = true
+	s.releaseJobs(args.Process)
+	s.notify()
+
+	return nil
+}
+
+// process returns the given process and records that it is alive. The lock of the search must be held.
+func (s *coordinatorSearch) process(id int) (*coordinatorProcess, error) {
+	if id < 0 || id >= len(s.processes) {
+		return nil, fmt.Errorf("unknown process %d", id)
+	}
+
+	p := s.processes[id]
+	p.LastSeen = time.Now()
+	p.Dead = false
+
+	return p, nil
+}
+
+// reapProcesses hands out the subtrees of processes which have not been seen for a lease again, until the search is finished.
+func (s *coordinatorSearch) reapProcesses() {
+	ticker := time.NewTicker(s.heartbeat)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.mu.Lock()
+
+		if s.finished {
+			s.mu.Unlock()
+
+			return
+		}
+
+		reaped := false
+		for id, p := range s.processes {
+			if p.Dead || p.Left || time.Since(p.LastSeen) < s.lease {
+				continue
+			}
+
+			p.Dead = true
+			if s.releaseJobs(id) {
+				reaped = true
+			}
+		}
+		if reaped {
+			s.notify()
+		}
+
+		s.mu.Unlock()
+	}
+}
+
+// releaseJobs hands out the unfinished subtrees of the given process again and returns if there were any. The lock of the search must be held.
+func (s *coordinatorSearch) releaseJobs(id int) bool {
+	released := false
+	for i := range s.jobs {
+		if job := &s.jobs[i]; job.Process == id && !job.Done {
+			job.Process = -1
+			s.pending = append(s.pending, i)
+			released = true
+		}
+	}
+
+	return released
+}
+
+// waitForProcesses waits until all processes have left or are dead, but at the latest for the given duration.
+func (s *coordinatorSearch) waitForProcesses(timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for time.Now().Before(deadline) {
+		gone := true
+		for _, p := range s.processes {
+			if !p.Left && !p.Dead && time.Since(p.LastSeen) < s.lease {
+				gone = false
+
+				break
+			}
+		}
+		if gone {
+			return
+		}
+
+		s.wait()
+	}
+}
+
+// wait waits until the state of the search changes, but at the latest for the heartbeat interval. The lock of the search must be held and is held again when wait returns.
+func (s *coordinatorSearch) wait() {
+	changed := s.changed
+	s.mu.Unlock()
+
+	timer := time.NewTimer(s.heartbeat)
+	select {
+	case <-changed:
+	case <-timer.C:
+	}
+	timer.Stop()
+
+	s.mu.Lock()
+}
+
+// notify wakes up all waiting calls. The lock of the search must be held.
+func (s *coordinatorSearch) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// finish marks the search as finished. The lock of the search must be held.
+func (s *coordinatorSearch) finish() {
+	if s.finished {
+		return
+	}
+
+	s.finished = true
+	close(s.done)
+	s.notify()
+}
+
+// reportProgress reports the current incumbent if progress is requested. The lock of the search must be held.
+func (s *coordinatorSearch) reportProgress() {
+	if s.progress == nil {
+		return
+	}
+
+	stats := s.stats
+	for _, p := range s.processes {
+		stats.add(&p.Stats)
+	}
+
+	s.progress(Progress{
+		Tour: &Tour{
+			Length: s.bestLength,
+			Order:  append([]int(nil), s.bestOrder...),
+		},
+		Elapsed:  time.Since(s.start),
+		Expanded: stats.Expanded,
+		Stats:    stats,
+	})
+}
+
+// RemoteWorker searches the subtrees which are handed out by a Coordinator.
+type RemoteWorker struct {
+	// Workers holds the number of subtrees which are searched concurrently. If it is zero, GOMAXPROCS subtrees are searched.
+	Workers int
+}
+
+// remoteSearch holds the state of a worker process which takes part in a coordinated search.
+type remoteSearch struct {
+	client  *rpc.Client
+	process int
+	graph   *Graph
+	bound   Bound
+	cancel  context.CancelFunc
+
+	// incumbent holds the length of the best cyclic path known by the process, noTour means that there is none. It must be accessed atomically.
+	incumbent int64
+
+	errLock sync.Mutex
+	err     error
+}
+
+// Work connects to the coordinator with the given address and searches subtrees until the search of the coordinator is finished or the given context is done.
+func (r *RemoteWorker) Work(ctx context.Context, address string) error {
+	workerLength := r.Workers
+	if workerLength <= 0 {
+		workerLength = runtime.GOMAXPROCS(-1)
+	}
+
+	client, err := rpc.Dial("tcp", address)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var join JoinReply
+	if err := client.Call("Coordinator.Join", JoinArgs{Workers: workerLength}, &join); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := &remoteSearch{
+		client:  client,
+		process: join.Process,
+		graph:   join.Graph,
+		bound:   join.Bound,
+		cancel:  cancel,
+
+		incumbent: noTour,
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(workerLength + 1)
+
+	go func() {
+		w.sendHeartbeats(ctx)
+		// The search is over once there are no heartbeats anymore.
+		cancel()
+
+		wg.Done()
+	}()
+	for i := 0; i < workerLength; i++ {
+		go func() {
+			w.work(ctx)
+
+			wg.Done()
+		}()
+	}
+
+	wg.Wait()
+
+	// Let the coordinator know that the process is gone, so it does not need to wait for its heartbeats.
+	_ = client.Call("Coordinator.Leave", LeaveArgs{Process: w.process}, &LeaveReply{})
+
+	return w.err
+}
+
+// sendHeartbeats sends heartbeats to the coordinator and receives improved incumbents until the search is finished or the given context is done.
+func (w *remoteSearch) sendHeartbeats(ctx context.Context) {
+	for {
+		var reply HeartbeatReply
+		call := w.client.Go("Coordinator.Heartbeat", HeartbeatArgs{
+			Process:   w.process,
+			Incumbent: int(atomic.LoadInt64(&w.incumbent)),
+		}, &reply, nil)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-call.Done:
+		}
+
+		if call.Error != nil {
+			w.fail(call.Error)
+
+			return
+		}
+
+		w.improve(reply.Incumbent)
+
+		if reply.Finished {
+			return
+		}
+	}
+}
+
+// work searches subtrees of the coordinator until the search is finished or the given context is done.
+func (w *remoteSearch) work(ctx context.Context) {
+	g := w.graph
+
+	bound, err := w.bound.newBounder(g)
+	if err != nil {
+		w.fail(err)
+
+		return
+	}
+
+	// The search and its stack are reused for every subtree of the process.
+	search := &sequentialSearch{
+		graph:     g,
+		out:       g.outgoing(),
+		bound:     bound,
+		incumbent: &w.incumbent,
+		progress: func(p Progress) {
+			w.report(p.Tour)
+		},
+	}
+	search.allocateStack()
+
+	args := NextArgs{
+		Process: w.process,
+		Job:     -1,
+	}
+	for {
+		var reply NextReply
+		call := w.client.Go("Coordinator.Next", args, &reply, nil)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-call.Done:
+		}
+
+		if call.Error != nil {
+			w.fail(call.Error)
+
+			return
+		} else if reply.Finished {
+			return
+		}
+
+		w.improve(reply.Incumbent)
+
+		args.Job = -1
+		if reply.Job == -1 {
+			continue
+		}
+
+		root, err := PathFromOrder(g, reply.Prefix)
+		if err != nil {
+			w.fail(err)
+
+			return
+		}
+
+		search.stats = Stats{}
+		search.start = time.Now()
+
+		release := search.stop.watch(ctx)
+		search.solveFrom(root)
+		release()
+
+		if search.stop.isSet() {
+			// The subtree is not finished, the coordinator hands it out again if needed.
+			return
+		}
+
+		args.Job = reply.Job
+		args.Stats = search.stats
+	}
+}
+
+// report reports the given improved tour to the coordinator.
+func (w *remoteSearch) report(t *Tour) {
+	w.improve(t.Length)
+
+	var reply ImproveReply
+	if err := w.client.Call("Coordinator.Improve", ImproveArgs{
+		Process: w.process,
+		Order:   t.Order,
+	}, &reply); err != nil {
+		w.fail(err)
+
+		return
+	}
+
+	w.improve(reply.Incumbent)
+}
+
+// improve records the given length as incumbent of the process if it is shorter than the current one.
+func (w *remoteSearch) improve(length int) {
+	if length == noTour {
+		return
+	}
+
+	for {
+		incumbent := atomic.LoadInt64(&w.incumbent)
+		if incumbent != noTour && incumbent <= int64(length) {
+			return
+		}
+
+		if atomic.CompareAndSwapInt64(&w.incumbent, incumbent, int64(length)) {
+			return
+		}
+	}
+}
+
+// fail records the given error as the result of the process if it is the first one and stops the process.
+func (w *remoteSearch) fail(err error) {
+	w.errLock.Lock()
+	if w.err == nil {
+		w.err = err
+	}
+	w.errLock.Unlock()
+
+	w.cancel()
+}
@@ -0,0 +1,182 @@
+package tsp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/rpc"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// solveDistributed solves the given graph with the given coordinator and the given number of worker processes on localhost.
+func solveDistributed(t *testing.T, c *Coordinator, g *Graph, processes int) *Tour {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	c.Listener = listener
+
+	var wg sync.WaitGroup
+	wg.Add(processes)
+	for i := 0; i < processes; i++ {
+		go func() {
+			assert.NoError(t, (&RemoteWorker{Workers: 2}).Work(context.Background(), listener.Addr().String()))
+
+			wg.Done()
+		}()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	tour, err := c.Solve(ctx, g)
+	assert.NoError(t, err)
+
+	wg.Wait()
+
+	return tour
+}
+
+func TestCoordinator(t *testing.T) {
+	g, err := ReadGraph("../graphs/08-18-nodes-fraction-90.graph")
+	assert.NoError(t, err)
+
+	tour := solveDistributed(t, &Coordinator{Bound: BoundReduced}, g, 3)
+
+	assert.True(t, tour.Optimal)
+	assert.Equal(t, 209, tour.Length)
+	assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+	assert.Len(t, tour.Stats.Workers, 3)
+}
+
+func TestCoordinatorRandomGraphs(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		g := randomGraph(seed, 9, 60)
+
+		expected, err := (&Sequential{}).Solve(context.Background(), g)
+		assert.NoError(t, err)
+
+		for _, splitDepth := range []int{1, 3, 20} {
+			t.Run(fmt.Sprintf("%d-%d", seed, splitDepth), func(t *testing.T) {
+				tour := solveDistributed(t, &Coordinator{SplitDepth: splitDepth}, g, 2)
+
+				assert.True(t, tour.Optimal)
+				if expected.Order == nil {
+					assert.Nil(t, tour.Order)
+				} else {
+					assert.Equal(t, expected.Length, tour.Length)
+					assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+				}
+			})
+		}
+	}
+}
+
+func TestCoordinatorZeroLengthEdges(t *testing.T) {
+	g := NewGraphFromMatrix([][]int{
+		{0, 1, 0, 0},
+		{0, 0, 1, 0},
+		{0, 0, 0, 1},
+		{1, 0, 0, 0},
+	})
+	g.SetEdge(1, 2, 0)
+	g.SetEdge(3, 0, 0)
+
+	tour := solveDistributed(t, &Coordinator{SplitDepth: 2}, g, 2)
+
+	assert.True(t, tour.Optimal)
+	assert.Equal(t, 2, tour.Length)
+	assert.Equal(t, []int{0, 1, 2, 3}, tour.Order)
+}
+
+func TestSequentialSearchReuse(t *testing.T) {
+	g := randomGraph(3, 8, 80)
+	reused := &sequentialSearch{
+		graph: g,
+		out:   g.outgoing(),
+		bound: noneBound{},
+	}
+	reused.allocateStack()
+	stack := reused.stack
+
+	// Every subtree must be searched by the reused search as by a new one, without a new stack.
+	for _, node := range reused.out[0] {
+		root, err := PathFromOrder(g, []int{0, node})
+		assert.NoError(t, err)
+
+		fresh := &sequentialSearch{
+			graph: g,
+			out:   g.outgoing(),
+			bound: noneBound{},
+		}
+		expected := fresh.solveFrom(root)
+
+		reused.stats = Stats{}
+		actual := reused.solveFrom(root)
+
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, fresh.stats, reused.stats)
+		assert.True(t, &stack[0] == &reused.stack[0])
+	}
+}
+
+func TestCoordinatorSingleNode(t *testing.T) {
+	tour := solveDistributed(t, &Coordinator{}, NewGraph(1), 2)
+
+	assert.True(t, tour.Optimal)
+	assert.Nil(t, tour.Order)
+}
+
+func TestCoordinatorLease(t *testing.T) {
+	for lease, expected := range map[time.Duration]string{
+		-time.Second: "lease must not be negative but is -1s",
+		4:            "lease must be at least 5ns but is 4ns",
+	} {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+
+		_, err = (&Coordinator{Listener: listener, Lease: lease}).Solve(context.Background(), NewGraph(3))
+		assert.EqualError(t, err, expected)
+	}
+}
+
+func TestCoordinatorDeadWorker(t *testing.T) {
+	g := randomGraph(3, 10, 80)
+
+	expected, err := (&Sequential{}).Solve(context.Background(), g)
+	assert.NoError(t, err)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	result := make(chan *Tour)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		tour, err := (&Coordinator{Listener: listener, Lease: 200 * time.Millisecond}).Solve(ctx, g)
+		assert.NoError(t, err)
+
+		result <- tour
+	}()
+
+	// The dead worker takes a subtree and disappears.
+	client, err := rpc.Dial("tcp", listener.Addr().String())
+	assert.NoError(t, err)
+
+	var join JoinReply
+	assert.NoError(t, client.Call("Coordinator.Join", JoinArgs{Workers: 1}, &join))
+	var next NextReply
+	assert.NoError(t, client.Call("Coordinator.Next", NextArgs{Process: join.Process, Job: -1}, &next))
+	assert.NotEqual(t, -1, next.Job)
+	assert.NoError(t, client.Close())
+
+	assert.NoError(t, (&RemoteWorker{Workers: 1}).Work(context.Background(), listener.Addr().String()))
+
+	tour := <-result
+	assert.True(t, tour.Optimal)
+	assert.Equal(t, expected.Length, tour.Length)
+	assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+}
@@ -0,0 +1,142 @@
+package tsp
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// isEdgeList returns if the given file content is an edge list, whose first line is either a comment or an edge of three fields ending with its length or an absent-edge marker.
+// Fields with colons are not accepted since the keywords of TSPLIB files look like edges otherwise, e.g. "DIMENSION : 14".
+func isEdgeList(data []byte) bool {
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(nil, len(data)+1)
+
+	for s.Scan() {
+		text := strings.TrimSpace(s.Text())
+		if text == "" {
+			continue
+		} else if strings.HasPrefix(text, "#") {
+			return true
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 3 || strings.Contains(text, ":") {
+			return false
+		}
+		_, err := strconv.Atoi(fields[2])
+
+		return err == nil || isAbsentEdge(fields[2])
+	}
+
+	return false
+}
+
+// readEdgeList reads in a graph given as edge list. Every line holds an edge as "from to length", lines beginning with "#" are comments and a line with a single field declares a node without adding an edge, which is needed for nodes without edges.
+// A length of "-", "inf" or "-1" marks a missing edge, which only declares its nodes.
+// If all nodes are non-negative integers they are used as node indices and the graph has as many nodes as the highest index plus one. Otherwise the nodes are labels which are numbered in the order of their first appearance, so the first node of the file is the start node of the search.
+func readEdgeList(data []byte) (*Graph, error) {
+	type edge struct {
+		from, to string
+		length   int
+		line     int
+	}
+
+	var edges []edge
+	var nodes []string
+	seen := make(map[string]bool)
+	addNode := func(node string) {
+		if !seen[node] {
+			seen[node] = true
+			nodes = append(nodes, node)
+		}
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(nil, len(data)+1)
+
+	line := 0
+	for s.Scan() {
+		line++
+
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		switch len(fields) {
+		case 1:
+			addNode(fields[0])
+		case 3:
+			if fields[0] == fields[1] {
+				return nil, fmt.Errorf("line %d of edge list: edge from node %s to itself", line, fields[0])
+			}
+
+			addNode(fields[0])
+			addNode(fields[1])
+			if isAbsentEdge(fields[2]) {
+				continue
+			}
+
+			length, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("line %d of edge list: invalid length %q", line, fields[2])
+			} else if length < 0 {
+				return nil, fmt.Errorf("line %d of edge list: length must not be negative but is %d", line, length)
+			}
+
+			edges = append(edges, edge{fields[0], fields[1], length, line})
+		default:
+			return nil, fmt.Errorf("line %d of edge list: expected \"from to length\" but found %d fields", line, len(fields))
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("edge list has no nodes")
+	}
+
+	// Use the nodes as indices if all of them are indices.
+	index := make(map[string]int, len(nodes))
+	numberOfNodes := 0
+	labeled := false
+	for _, node := range nodes {
+		i, err := strconv.Atoi(node)
+		if err != nil || i < 0 || strconv.Itoa(i) != node {
+			labeled = true
+
+			break
+		}
+
+		index[node] = i
+		if i >= numberOfNodes {
+			numberOfNodes = i + 1
+		}
+	}
+
+	var g *Graph
+	if labeled {
+		g = NewGraph(len(nodes))
+		g.Labels = nodes
+		for i, node := range nodes {
+			index[node] = i
+		}
+	} else {
+		g = NewGraph(numberOfNodes)
+	}
+
+	for _, e := range edges {
+		from, to := index[e.from], index[e.to]
+		if g.HasEdge(from, to) {
+			return nil, fmt.Errorf("line %d of edge list: edge from node %s to node %s is given twice", e.line, e.from, e.to)
+		}
+
+		g.SetEdge(from, to, e.length)
+	}
+
+	return g, nil
+}
@@ -0,0 +1,97 @@
+package tsp
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadEdgeList(t *testing.T) {
+	directory, err := ioutil.TempDir("", "edgelist")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+
+	file := filepath.Join(directory, "graph")
+
+	// The edges of 01-original.graph.
+	assert.NoError(t, ioutil.WriteFile(file, []byte("0 1 1\n0 2 3\n0 3 8\n1 0 5\n1 2 2\n1 3 6\n2 0 1\n2 1 18\n2 3 10\n3 0 7\n3 1 4\n3 2 12\n"), 0644))
+	g, err := ReadGraph(file)
+	assert.NoError(t, err)
+	expected, err := ReadGraph("../graphs/01-original.graph")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, g)
+
+	// Named nodes are numbered in the order of their first appearance.
+	assert.NoError(t, ioutil.WriteFile(file, []byte("# Labeled 01-original.graph\na b 1\na c 3\na d 8\nb a 5\nb c 2\nb d 6\nc a 1\nc b 18\nc d 10\nd a 7\nd b 4\nd c 12\n"), 0644))
+	g, err = ReadGraph(file)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.Matrix, g.Matrix)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, g.Labels)
+
+	tour, err := (&Sequential{}).Solve(context.Background(), g)
+	assert.NoError(t, err)
+	assert.Equal(t, 15, tour.Length)
+	assert.Equal(t, "a->d->b->c->a", tour.LabeledString(g))
+
+	tourFile := filepath.Join(directory, "tour")
+	assert.NoError(t, ioutil.WriteFile(tourFile, []byte("a->d->b->c->a\n"), 0644))
+	read, err := ReadTour(g, tourFile)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 3, 1, 2}, read.Order)
+
+	// Nodes without edges must be declared.
+	assert.NoError(t, ioutil.WriteFile(file, []byte("0 1 1\n1 0 1\n2\n"), 0644))
+	g, err = ReadGraph(file)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, g.NumberOfNodes)
+	assert.Nil(t, g.Labels)
+
+	tour, err = (&Parallel{Workers: 2}).Solve(context.Background(), g)
+	assert.NoError(t, err)
+	assert.Nil(t, tour.Order)
+}
+
+func TestReadEdgeListErrors(t *testing.T) {
+	directory, err := ioutil.TempDir("", "edgelist")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+
+	for name, content := range map[string]string{
+		"Length":    "a b x\n",
+		"Negative":  "# Comment\na b -2\n",
+		"Loop":      "a a 1\n",
+		"Duplicate": "a b 1\nb a 1\na b 2\n",
+		"Fields":    "a b 1\na b\n",
+		"Empty":     "# Comment\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(directory, name)
+			assert.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+
+			_, err := ReadGraph(file)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestIsEdgeList(t *testing.T) {
+	assert.True(t, isEdgeList([]byte("\n0 1 5\n")))
+	assert.True(t, isEdgeList([]byte("# Comment\n4\n")))
+	assert.False(t, isEdgeList([]byte("4\n0 1 3 8\n")))
+	assert.False(t, isEdgeList([]byte("DIMENSION : 14\n")))
+	assert.False(t, isEdgeList([]byte("NAME: burma14\n")))
+}
+
+func TestOutgoing(t *testing.T) {
+	g := NewGraph(3)
+	g.SetEdge(0, 2, 4)
+	g.SetEdge(0, 1, 0)
+	g.SetEdge(2, 0, 1)
+	g.SetEdge(1, 1, 9)
+
+	assert.Equal(t, [][]int{{1, 2}, nil, {0}}, g.outgoing())
+}
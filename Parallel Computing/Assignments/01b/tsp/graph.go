@@ -0,0 +1,227 @@
+package tsp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+// Graph holds a directed graph as an adjacency matrix.
+type Graph struct {
+	// NumberOfNodes holds the number of nodes of the graph.
+	NumberOfNodes int
+	// Matrix holds the length of the edge from node y to node x in Matrix[y][x]. The length of a missing edge is zero.
+	Matrix [][]int
+	// Edges holds in Edges[y][x] if there is an edge from node y to node x, since an edge can have a length of zero.
+	Edges [][]bool
+	// Labels holds the name of every node if the graph has been read in from an edge list with named nodes, otherwise it is nil.
+	Labels []string
+}
+
+// NewGraph returns a graph with the given number of nodes and without any edges.
+func NewGraph(numberOfNodes int) *Graph {
+	g := &Graph{
+		NumberOfNodes: numberOfNodes,
+		Matrix:        make([][]int, numberOfNodes),
+		Edges:         make([][]bool, numberOfNodes),
+	}
+	for y := 0; y < numberOfNodes; y++ {
+		g.Matrix[y] = make([]int, numberOfNodes)
+		g.Edges[y] = make([]bool, numberOfNodes)
+	}
+
+	return g
+}
+
+// NewGraphFromMatrix returns a graph with the given adjacency matrix in which an edge of length zero means that there is no edge, as in the graph files without absent-edge markers.
+func NewGraphFromMatrix(matrix [][]int) *Graph {
+	g := NewGraph(len(matrix))
+	for y, row := range matrix {
+		for x, length := range row {
+			if x != y && length != 0 {
+				g.SetEdge(y, x, length)
+			}
+		}
+	}
+
+	return g
+}
+
+// HasEdge returns if there is an edge from the given node to the other given node.
+func (g *Graph) HasEdge(from int, to int) bool {
+	return g.Edges[from][to]
+}
+
+// SetEdge adds the edge from the given node to the other given node with the given length, or changes its length if it exists already.
+func (g *Graph) SetEdge(from int, to int, length int) {
+	g.Matrix[from][to] = length
+	g.Edges[from][to] = true
+}
+
+// RemoveEdge removes the edge from the given node to the other given node.
+func (g *Graph) RemoveEdge(from int, to int) {
+	g.Matrix[from][to] = 0
+	g.Edges[from][to] = false
+}
+
+// hasZeroEdges returns if the graph has an edge of length zero, which cannot be written without absent-edge markers.
+func (g *Graph) hasZeroEdges() bool {
+	for y := 0; y < g.NumberOfNodes; y++ {
+		for x := 0; x < g.NumberOfNodes; x++ {
+			if g.HasEdge(y, x) && g.Matrix[y][x] == 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// longestEdge returns the length of the longest edge of the graph, or zero if there are no edges.
+func (g *Graph) longestEdge() int {
+	longest := 0
+	for y := 0; y < g.NumberOfNodes; y++ {
+		for x := 0; x < g.NumberOfNodes; x++ {
+			if g.HasEdge(y, x) && g.Matrix[y][x] > longest {
+				longest = g.Matrix[y][x]
+			}
+		}
+	}
+
+	return longest
+}
+
+// isAbsentEdge returns if the given field of a graph file marks a missing edge.
+func isAbsentEdge(field string) bool {
+	switch strings.ToLower(field) {
+	case "-", "inf", "-1":
+		return true
+	}
+
+	return false
+}
+
+// Label returns the name of the given node, which is its index if the nodes of the graph have no labels.
+func (g *Graph) Label(node int) string {
+	if g.Labels == nil {
+		return strconv.Itoa(node)
+	}
+
+	return g.Labels[node]
+}
+
+// outgoing returns for every node the nodes its outgoing edges lead to in ascending order, so a search only has to look at existing edges.
+func (g *Graph) outgoing() [][]int {
+	out := make([][]int, g.NumberOfNodes)
+	for y := 0; y < g.NumberOfNodes; y++ {
+		for x := 0; x < g.NumberOfNodes; x++ {
+			if x != y && g.HasEdge(y, x) {
+				out[y] = append(out[y], x)
+			}
+		}
+	}
+
+	return out
+}
+
+// ReadGraph reads in the graph of the given file. The file either holds the number of nodes followed by the adjacency matrix, an edge list with one "from to length" line per edge, or a problem in the TSPLIB format.
+// Missing edges of the adjacency matrix are marked with "-", "inf" or "-1". Files without any marker use zero for missing edges instead, so edges of length zero need the markers.
+func ReadGraph(filepath string) (*Graph, error) {
+	data, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	if isEdgeList(data) {
+		return readEdgeList(data)
+	} else if isTSPLIB(data) {
+		return readTSPLIB(data)
+	}
+
+	f := bytes.NewReader(data)
+
+	// Read in the number of nodes.
+	var numberOfNodes int
+	_, err = fmt.Fscanln(f, &numberOfNodes)
+	if err != nil {
+		return nil, err
+	} else if numberOfNodes < 1 {
+		return nil, fmt.Errorf("number of nodes must be positive but is %d", numberOfNodes)
+	}
+
+	// Initialize the matrix.
+	g := NewGraph(numberOfNodes)
+	markers := false
+
+	// Read in the matrix. Do nothing special, it is not the point to optimize this.
+	for y := 0; y < numberOfNodes; y++ {
+		for x := 0; x < numberOfNodes; x++ {
+			var field string
+			_, err = fmt.Fscan(f, &field)
+			if err != nil {
+				return nil, err
+			}
+
+			if isAbsentEdge(field) {
+				markers = true
+
+				continue
+			}
+
+			length, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid length %q of the edge from node %d to node %d", field, y, x)
+			} else if length < 0 {
+				return nil, fmt.Errorf("edge from node %d to node %d has the negative length %d", y, x, length)
+			}
+
+			if x != y {
+				g.SetEdge(y, x, length)
+			}
+		}
+	}
+
+	if !markers {
+		return NewGraphFromMatrix(g.Matrix), nil
+	}
+
+	return g, nil
+}
+
+// Write writes the graph in the format understood by ReadGraph to the given writer. Missing edges are written as zero unless the graph has edges of length zero, then they are marked with "-".
+func (g *Graph) Write(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, g.NumberOfNodes); err != nil {
+		return err
+	}
+
+	absent := "0"
+	if g.hasZeroEdges() {
+		absent = "-"
+	}
+
+	for y := 0; y < g.NumberOfNodes; y++ {
+		for x := 0; x < g.NumberOfNodes; x++ {
+			field := absent
+			if g.HasEdge(y, x) {
+				field = strconv.Itoa(g.Matrix[y][x])
+			}
+
+			if _, err := fmt.Fprint(w, field); err != nil {
+				return err
+			}
+			if x != g.NumberOfNodes-1 {
+				if _, err := fmt.Fprint(w, "\t"); err != nil {
+					return err
+				}
+			}
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
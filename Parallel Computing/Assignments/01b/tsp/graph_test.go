@@ -0,0 +1,91 @@
+package tsp
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadGraph(t *testing.T) {
+	g, err := ReadGraph("../graphs/01-original.graph")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 4, g.NumberOfNodes)
+	assert.Equal(
+		t,
+		[][]int{
+			{0, 1, 3, 8},
+			{5, 0, 2, 6},
+			{1, 18, 0, 10},
+			{7, 4, 12, 0},
+		},
+		g.Matrix,
+	)
+	assert.True(t, g.HasEdge(0, 1))
+	assert.False(t, g.HasEdge(1, 1))
+}
+
+func TestWriteGraph(t *testing.T) {
+	g, err := ReadGraph("../graphs/01-original.graph")
+	assert.NoError(t, err)
+
+	expected, err := ioutil.ReadFile("../graphs/01-original.graph")
+	assert.NoError(t, err)
+
+	var b bytes.Buffer
+	assert.NoError(t, g.Write(&b))
+	assert.Equal(t, string(expected), b.String())
+}
+
+func TestReadGraphAbsentEdges(t *testing.T) {
+	directory, err := ioutil.TempDir("", "graph")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+
+	file := filepath.Join(directory, "graph")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("3\n- 0 5\ninf - -1\n2 0 INF\n"), 0644))
+
+	g, err := ReadGraph(file)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]bool{
+		{false, true, true},
+		{false, false, false},
+		{true, true, false},
+	}, g.Edges)
+	assert.Equal(t, [][]int{
+		{0, 0, 5},
+		{0, 0, 0},
+		{2, 0, 0},
+	}, g.Matrix)
+
+	// Edges of length zero are written with markers for the missing edges.
+	var b bytes.Buffer
+	assert.NoError(t, g.Write(&b))
+	assert.Equal(t, "3\n-\t0\t5\n-\t-\t-\n2\t0\t-\n", b.String())
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("2\n0 -2\n1 0\n"), 0644))
+	_, err = ReadGraph(file)
+	assert.Error(t, err)
+}
+
+func TestReadGraphNumberOfNodes(t *testing.T) {
+	directory, err := ioutil.TempDir("", "graph")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+
+	file := filepath.Join(directory, "graph")
+	for _, data := range []string{"0\n", "-3\n"} {
+		assert.NoError(t, ioutil.WriteFile(file, []byte(data), 0644))
+		_, err = ReadGraph(file)
+		assert.Error(t, err)
+	}
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("1\n0\n"), 0644))
+	g, err := ReadGraph(file)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, g.NumberOfNodes)
+}
@@ -0,0 +1,245 @@
+package tsp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/bits"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// HeldKarpMaxNodes holds the maximum number of nodes a graph can have to be solved by HeldKarp. The table for 25 nodes already needs 1.6 GB of memory.
+const HeldKarpMaxNodes = 25
+
+// heldKarpBlockSize holds the number of subsets which are handed out at once to a worker.
+const heldKarpBlockSize = 1024
+
+// heldKarpInfinity marks table entries for which there is no path.
+const heldKarpInfinity = math.MaxInt32
+
+// HeldKarp solves graphs with the dynamic programming algorithm of Held and Karp in O(n²·2ⁿ) time and O(n·2ⁿ) memory.
+// The subsets of every layer, i.e. subsets with the same number of nodes, are distributed over multiple goroutines.
+// The Expanded statistic of its tours holds the number of computed table entries.
+// The table holds 32-bit lengths, so the edges of a graph with n nodes must not be longer than (math.MaxInt32-1)/n.
+type HeldKarp struct {
+	// Workers holds the number of worker goroutines. If it is zero, GOMAXPROCS workers are used.
+	Workers int
+}
+
+// heldKarpSearch holds the state of one Held-Karp search.
+type heldKarpSearch struct {
+	graph *Graph
+	stop  stopFlag
+
+	// m holds the number of nodes without the start node 0. Node i+1 of the graph is represented by bit i of a subset.
+	m int
+	// table holds at table[subset*m+j] the length of the shortest path starting at node 0, visiting exactly the nodes of the subset and ending at node j+1.
+	table []int32
+
+	// nextBlock holds the next block of subsets of the current layer which has not been handed out to a worker. It must be accessed atomically.
+	nextBlock int64
+	// computed holds the number of computed table entries. It must be accessed atomically.
+	computed int64
+}
+
+// Solve finds the shortest cyclic path visiting all nodes in the given graph.
+func (s *HeldKarp) Solve(ctx context.Context, g *Graph) (*Tour, error) {
+	if g.NumberOfNodes < 2 {
+		return nil, fmt.Errorf("graph must have at least 2 nodes but has %d", g.NumberOfNodes)
+	}
+	if g.NumberOfNodes > HeldKarpMaxNodes {
+		return nil, fmt.Errorf("graph must have at most %d nodes but has %d", HeldKarpMaxNodes, g.NumberOfNodes)
+	}
+	// The table holds 32-bit lengths, so even a cyclic path of only the longest edges must fit.
+	if maxLength, longest := (heldKarpInfinity-1)/g.NumberOfNodes, g.longestEdge(); longest > maxLength {
+		return nil, fmt.Errorf("graph with %d nodes must have edges of at most length %d but has an edge of length %d", g.NumberOfNodes, maxLength, longest)
+	}
+
+	workerLength := s.Workers
+	if workerLength <= 0 {
+		workerLength = runtime.GOMAXPROCS(-1)
+	}
+
+	m := g.NumberOfNodes - 1
+	search := &heldKarpSearch{
+		graph: g,
+		m:     m,
+		table: make([]int32, (1<<uint(m))*m),
+	}
+
+	release := search.stop.watch(ctx)
+	search.solve(workerLength)
+	release()
+
+	if search.stop.isSet() {
+		// The table is not complete, so there is no result at all.
+		return &Tour{
+			Stats: Stats{
+				Expanded: search.computed,
+			},
+		}, nil
+	}
+
+	tour := search.tour()
+	tour.Optimal = true
+	tour.Stats.Expanded = search.computed
+
+	return tour, nil
+}
+
+// solve fills the table layer by layer using the given number of workers.
+func (s *heldKarpSearch) solve(workerLength int) {
+	g := s.graph
+
+	// Fill in the first layer which holds the direct edges from the start node.
+	for j := 0; j < s.m; j++ {
+		subset := 1 << uint(j)
+		if g.HasEdge(0, j+1) {
+			s.table[subset*s.m+j] = int32(g.Matrix[0][j+1])
+		} else {
+			s.table[subset*s.m+j] = heldKarpInfinity
+		}
+	}
+	s.computed = int64(s.m)
+
+	// Every layer only depends on the previous one, so all subsets of a layer can be computed concurrently.
+	for size := 2; size <= s.m; size++ {
+		if s.stop.isSet() {
+			return
+		}
+
+		s.nextBlock = 0
+
+		if workerLength == 1 {
+			s.solveLayer(size)
+
+			continue
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(workerLength)
+
+		for i := 0; i < workerLength; i++ {
+			go func() {
+				s.solveLayer(size)
+
+				wg.Done()
+			}()
+		}
+
+		wg.Wait()
+	}
+}
+
+// solveLayer computes blocks of table entries for subsets with the given size until all blocks of the layer are handed out.
+func (s *heldKarpSearch) solveLayer(size int) {
+	g := s.graph
+	subsets := int64(1) << uint(s.m)
+
+	var computed int64
+
+	for !s.stop.isSet() {
+		first := atomic.AddInt64(&s.nextBlock, heldKarpBlockSize) - heldKarpBlockSize
+		if first >= subsets {
+			break
+		}
+		last := first + heldKarpBlockSize
+		if last > subsets {
+			last = subsets
+		}
+
+		for subset := int(first); subset < int(last); subset++ {
+			if bits.OnesCount(uint(subset)) != size {
+				continue
+			}
+
+			for j := 0; j < s.m; j++ {
+				if subset&(1<<uint(j)) == 0 {
+					continue
+				}
+
+				// Find the shortest path over the subset without node j+1 that can be extended to node j+1.
+				previous := subset &^ (1 << uint(j))
+				best := int64(heldKarpInfinity)
+				for k := 0; k < s.m; k++ {
+					if previous&(1<<uint(k)) == 0 || !g.HasEdge(k+1, j+1) {
+						continue
+					}
+
+					length := s.table[previous*s.m+k]
+					if length == heldKarpInfinity {
+						continue
+					}
+
+					if l := int64(length) + int64(g.Matrix[k+1][j+1]); l < best {
+						best = l
+					}
+				}
+
+				s.table[subset*s.m+j] = int32(best)
+				computed++
+			}
+		}
+	}
+
+	atomic.AddInt64(&s.computed, computed)
+}
+
+// tour reconstructs the shortest cyclic path of the completed table.
+func (s *heldKarpSearch) tour() *Tour {
+	g := s.graph
+	full := (1 << uint(s.m)) - 1
+
+	// Find the best last node before returning to the start node.
+	best := int64(heldKarpInfinity)
+	last := -1
+	for j := 0; j < s.m; j++ {
+		length := s.table[full*s.m+j]
+		if length == heldKarpInfinity || !g.HasEdge(j+1, 0) {
+			continue
+		}
+
+		if l := int64(length) + int64(g.Matrix[j+1][0]); l < best {
+			best = l
+			last = j
+		}
+	}
+	if last == -1 {
+		// There is no cyclic path.
+		return &Tour{}
+	}
+
+	// Walk the table backwards by finding the predecessor that led to the length of each entry.
+	order := make([]int, g.NumberOfNodes)
+	subset := full
+	for i := g.NumberOfNodes - 1; i > 0; i-- {
+		order[i] = last + 1
+
+		previous := subset &^ (1 << uint(last))
+		if previous == 0 {
+			break
+		}
+
+		length := s.table[subset*s.m+last]
+		for k := 0; k < s.m; k++ {
+			if previous&(1<<uint(k)) == 0 || !g.HasEdge(k+1, last+1) || s.table[previous*s.m+k] == heldKarpInfinity {
+				continue
+			}
+
+			if int64(s.table[previous*s.m+k])+int64(g.Matrix[k+1][last+1]) == int64(length) {
+				last = k
+
+				break
+			}
+		}
+		subset = previous
+	}
+	order[0] = 0
+
+	return &Tour{
+		Length: int(best),
+		Order:  order,
+	}
+}
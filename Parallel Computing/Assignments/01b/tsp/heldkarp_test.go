@@ -0,0 +1,97 @@
+package tsp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeldKarp(t *testing.T) {
+	g, err := ReadGraph("../graphs/01-original.graph")
+	assert.NoError(t, err)
+
+	for _, workers := range []int{1, 4} {
+		tour, err := (&HeldKarp{Workers: workers}).Solve(context.Background(), g)
+		assert.NoError(t, err)
+
+		assert.Equal(t, 15, tour.Length)
+		assert.Equal(t, []int{0, 3, 1, 2}, tour.Order)
+		assert.True(t, tour.Optimal)
+	}
+}
+
+func TestHeldKarpRandomGraphs(t *testing.T) {
+	for seed := int64(0); seed < 30; seed++ {
+		g := randomGraph(seed, 8, 40)
+
+		expected, err := (&Sequential{}).Solve(context.Background(), g)
+		assert.NoError(t, err)
+
+		for _, workers := range []int{1, 3} {
+			t.Run(fmt.Sprintf("%d-%d", seed, workers), func(t *testing.T) {
+				tour, err := (&HeldKarp{Workers: workers}).Solve(context.Background(), g)
+				assert.NoError(t, err)
+
+				assert.True(t, tour.Optimal)
+				if expected.Order == nil {
+					assert.Nil(t, tour.Order)
+				} else {
+					assert.Equal(t, expected.Length, tour.Length)
+					assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+				}
+			})
+		}
+	}
+}
+
+func TestHeldKarpGraphs(t *testing.T) {
+	for _, tc := range []struct {
+		file   string
+		length int
+	}{
+		{"../graphs/06-18-nodes-fraction-100.graph", 210},
+		{"../graphs/07-18-nodes-fraction-100.graph", 232},
+		{"../graphs/08-18-nodes-fraction-90.graph", 209},
+	} {
+		g, err := ReadGraph(tc.file)
+		assert.NoError(t, err)
+
+		tour, err := (&HeldKarp{}).Solve(context.Background(), g)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tc.length, tour.Length)
+		assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+	}
+}
+
+func TestHeldKarpLimits(t *testing.T) {
+	_, err := (&HeldKarp{}).Solve(context.Background(), NewGraph(1))
+	assert.EqualError(t, err, "graph must have at least 2 nodes but has 1")
+
+	_, err = (&HeldKarp{}).Solve(context.Background(), NewGraph(HeldKarpMaxNodes+1))
+	assert.EqualError(t, err, fmt.Sprintf("graph must have at most %d nodes but has %d", HeldKarpMaxNodes, HeldKarpMaxNodes+1))
+
+	// The lengths of the table would overflow.
+	g := NewGraph(3)
+	g.SetEdge(0, 1, 1)
+	g.SetEdge(1, 2, 1)
+	g.SetEdge(2, 0, (math.MaxInt32-1)/3+1)
+	_, err = (&HeldKarp{}).Solve(context.Background(), g)
+	assert.EqualError(t, err, fmt.Sprintf("graph with 3 nodes must have edges of at most length %d but has an edge of length %d", (math.MaxInt32-1)/3, (math.MaxInt32-1)/3+1))
+
+	g.SetEdge(2, 0, (math.MaxInt32-1)/3)
+	tour, err := (&HeldKarp{}).Solve(context.Background(), g)
+	assert.NoError(t, err)
+	assert.Equal(t, 2+(math.MaxInt32-1)/3, tour.Length)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tour, err = (&HeldKarp{}).Solve(ctx, randomGraph(0, 10, 100))
+	assert.NoError(t, err)
+	assert.False(t, tour.Optimal)
+	assert.Nil(t, tour.Order)
+}
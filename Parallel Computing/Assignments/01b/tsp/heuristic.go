@@ -0,0 +1,370 @@
+package tsp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// WarmStart returns an initial tour for the given graph which is used as the initial bound of a search. It returns nil if it finds no tour.
+type WarmStart func(g *Graph) (*Tour, error)
+
+// Heuristic names a construction heuristic for an initial tour.
+type Heuristic string
+
+const (
+	// HeuristicNone does not construct a tour.
+	HeuristicNone Heuristic = "none"
+	// HeuristicNearestNeighbour always continues with the nearest unvisited node, starting from every node once.
+	HeuristicNearestNeighbour Heuristic = "nearest"
+	// HeuristicGreedy adds the shortest edges first as long as they do not close a cycle too early.
+	HeuristicGreedy Heuristic = "greedy"
+)
+
+// Heuristics holds all available heuristics.
+var Heuristics = []Heuristic{HeuristicNone, HeuristicNearestNeighbour, HeuristicGreedy}
+
+// HeuristicWarmStart returns a warm start which constructs a tour with the given heuristic and optionally improves it with 2-opt and Or-opt moves.
+func HeuristicWarmStart(h Heuristic, improve bool) (WarmStart, error) {
+	var construct func(g *Graph) *Tour
+	switch h {
+	case "", HeuristicNone:
+		return nil, nil
+	case HeuristicNearestNeighbour:
+		construct = NearestNeighbour
+	case HeuristicGreedy:
+		construct = GreedyEdge
+	default:
+		return nil, fmt.Errorf("unknown heuristic %q", string(h))
+	}
+
+	return func(g *Graph) (*Tour, error) {
+		t := construct(g)
+		if t != nil && improve {
+			t = Improve(g, t)
+		}
+
+		return t, nil
+	}, nil
+}
+
+// TourFileWarmStart returns a warm start which reads in the tour of the given file with ReadTour.
+func TourFileWarmStart(filepath string) WarmStart {
+	return func(g *Graph) (*Tour, error) {
+		return ReadTour(g, filepath)
+	}
+}
+
+// BestWarmStart returns a warm start which returns the shortest tour of the given warm starts.
+func BestWarmStart(warmStarts ...WarmStart) WarmStart {
+	return func(g *Graph) (*Tour, error) {
+		var best *Tour
+		for _, w := range warmStarts {
+			t, err := w(g)
+			if err != nil {
+				return nil, err
+			}
+
+			if t != nil && (best == nil || t.Length < best.Length) {
+				best = t
+			}
+		}
+
+		return best, nil
+	}
+}
+
+// warmStartPath returns the path of the tour found by the given warm start, the path starts at node 0 as the paths of the search do.
+// It returns nil if there is no warm start or if it found no tour.
+func warmStartPath(g *Graph, w WarmStart) (*Path, error) {
+	if w == nil {
+		return nil, nil
+	}
+
+	t, err := w(g)
+	if err != nil {
+		return nil, err
+	} else if t == nil {
+		return nil, nil
+	}
+
+	order := rotateOrder(t.Order, 0)
+	p, err := PathFromOrder(g, append(order, order[0]))
+	if err != nil {
+		return nil, err
+	} else if p.OrderLength != g.NumberOfNodes+1 {
+		return nil, fmt.Errorf("initial tour %v does not visit all nodes", t.Order)
+	}
+
+	return p, nil
+}
+
+// rotateOrder returns a copy of the given cyclic path which begins with the given node.
+func rotateOrder(order []int, start int) []int {
+	rotated := make([]int, 0, len(order)+1)
+	for i, node := range order {
+		if node == start {
+			rotated = append(rotated, order[i:]...)
+			rotated = append(rotated, order[:i]...)
+
+			return rotated
+		}
+	}
+
+	return append(rotated, order...)
+}
+
+// ReadTour reads in a tour for the given graph from the given file. The nodes of the tour can be separated by whitespace or by "->" as printed by the solver programs, the return to the start node is optional.
+// If the nodes of the graph have labels, the tour is given with the labels.
+func ReadTour(g *Graph, filepath string) (*Tour, error) {
+	data, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := make(map[string]int, len(g.Labels))
+	for i, label := range g.Labels {
+		labels[label] = i
+	}
+
+	var order []int
+	for _, field := range strings.Fields(strings.Replace(string(data), "->", " ", -1)) {
+		var node int
+		if g.Labels != nil {
+			var ok bool
+			node, ok = labels[field]
+			if !ok {
+				return nil, fmt.Errorf("unknown node %q in tour file %q", field, filepath)
+			}
+		} else {
+			node, err = strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("invalid node %q in tour file %q", field, filepath)
+			}
+		}
+
+		order = append(order, node)
+	}
+	if len(order) == g.NumberOfNodes+1 && order[0] == order[len(order)-1] {
+		order = order[:len(order)-1]
+	}
+	if len(order) != g.NumberOfNodes {
+		return nil, fmt.Errorf("tour of file %q has %d nodes but the graph has %d", filepath, len(order), g.NumberOfNodes)
+	}
+
+	length := tourLength(g, order)
+	if length == -1 {
+		return nil, fmt.Errorf("tour of file %q is not a cyclic path of the graph", filepath)
+	}
+
+	return &Tour{
+		Length: length,
+		Order:  order,
+	}, nil
+}
+
+// tourLength returns the length of the given cyclic path or -1 if it does not visit every node exactly once using existing edges.
+func tourLength(g *Graph, order []int) int {
+	if len(order) != g.NumberOfNodes {
+		return -1
+	}
+
+	visited := make([]bool, g.NumberOfNodes)
+	length := 0
+	for i, from := range order {
+		to := order[(i+1)%len(order)]
+		if from < 0 || from >= g.NumberOfNodes || visited[from] || to < 0 || to >= g.NumberOfNodes || !g.HasEdge(from, to) {
+			return -1
+		}
+		visited[from] = true
+
+		length += g.Matrix[from][to]
+	}
+
+	return length
+}
+
+// NearestNeighbour constructs a tour by always continuing with the nearest unvisited node. Since dead ends are common in graphs with missing edges, every node is tried as start node and the shortest tour is returned. It returns nil if no tour is found.
+func NearestNeighbour(g *Graph) *Tour {
+	var best *Tour
+
+	order := make([]int, g.NumberOfNodes)
+	visited := make([]bool, g.NumberOfNodes)
+
+	for start := 0; start < g.NumberOfNodes; start++ {
+		for i := range visited {
+			visited[i] = false
+		}
+
+		order[0] = start
+		visited[start] = true
+
+		complete := true
+		for i := 1; i < g.NumberOfNodes; i++ {
+			from := order[i-1]
+
+			next := -1
+			for to := 0; to < g.NumberOfNodes; to++ {
+				if visited[to] || !g.HasEdge(from, to) {
+					continue
+				}
+
+				if next == -1 || g.Matrix[from][to] < g.Matrix[from][next] {
+					next = to
+				}
+			}
+			if next == -1 {
+				// Dead end.
+				complete = false
+
+				break
+			}
+
+			order[i] = next
+			visited[next] = true
+		}
+		if !complete {
+			continue
+		}
+
+		length := tourLength(g, order)
+		if length != -1 && (best == nil || length < best.Length) {
+			best = &Tour{
+				Length: length,
+				Order:  rotateOrder(order, 0),
+			}
+		}
+	}
+
+	return best
+}
+
+// GreedyEdge constructs a tour by adding the shortest edges first as long as every node has at most one outgoing and one incoming edge and no cycle is closed before all nodes are connected. It returns nil if no tour is found.
+func GreedyEdge(g *Graph) *Tour {
+	type edge struct {
+		from, to int
+	}
+
+	var edges []edge
+	for y := 0; y < g.NumberOfNodes; y++ {
+		for x := 0; x < g.NumberOfNodes; x++ {
+			if x != y && g.HasEdge(y, x) {
+				edges = append(edges, edge{y, x})
+			}
+		}
+	}
+	sort.SliceStable(edges, func(i, j int) bool {
+		return g.Matrix[edges[i].from][edges[i].to] < g.Matrix[edges[j].from][edges[j].to]
+	})
+
+	next := make([]int, g.NumberOfNodes)
+	previous := make([]int, g.NumberOfNodes)
+	// end holds for the first node of a fragment its last node and the other way around.
+	end := make([]int, g.NumberOfNodes)
+	for i := 0; i < g.NumberOfNodes; i++ {
+		next[i] = -1
+		previous[i] = -1
+		end[i] = i
+	}
+
+	added := 0
+	for _, e := range edges {
+		if next[e.from] != -1 || previous[e.to] != -1 {
+			continue
+		}
+		// Adding the edge to the first node of the own fragment closes a cycle, which is only allowed for the last edge.
+		if end[e.from] == e.to && added != g.NumberOfNodes-1 {
+			continue
+		}
+
+		next[e.from] = e.to
+		previous[e.to] = e.from
+		added++
+
+		if added == g.NumberOfNodes {
+			break
+		}
+
+		// Join the fragments.
+		first := end[e.from]
+		last := end[e.to]
+		end[first] = last
+		end[last] = first
+	}
+	if added != g.NumberOfNodes {
+		return nil
+	}
+
+	order := make([]int, g.NumberOfNodes)
+	for i := 1; i < g.NumberOfNodes; i++ {
+		order[i] = next[order[i-1]]
+	}
+
+	return &Tour{
+		Length: tourLength(g, order),
+		Order:  order,
+	}
+}
+
+// Improve improves the given tour with 2-opt and Or-opt moves until no move shortens the tour anymore. Moves that would use missing edges are not made.
+// Since the graph can be asymmetric, the length of every candidate tour is computed completely.
+func Improve(g *Graph, t *Tour) *Tour {
+	n := len(t.Order)
+
+	best := make([]int, n)
+	copy(best, t.Order)
+	bestLength := tourLength(g, best)
+
+	candidate := make([]int, n)
+	moved := make([]int, 0, n)
+
+	for improved := true; improved; {
+		improved = false
+
+		// 2-opt moves reverse the segment from i to j.
+		for i := 1; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				copy(candidate, best)
+				for a, b := i, j; a < b; a, b = a+1, b-1 {
+					candidate[a], candidate[b] = candidate[b], candidate[a]
+				}
+
+				if length := tourLength(g, candidate); length != -1 && length < bestLength {
+					copy(best, candidate)
+					bestLength = length
+					improved = true
+				}
+			}
+		}
+
+		// Or-opt moves take out the segment of length 1 to 3 beginning at i and insert it before position j of the remaining nodes.
+		for length := 1; length <= 3 && length < n-1; length++ {
+			for i := 1; i+length <= n; i++ {
+				for j := 1; j <= n-length; j++ {
+					if j == i {
+						continue
+					}
+
+					rest := append(append(candidate[:0], best[:i]...), best[i+length:]...)
+					segment := best[i : i+length]
+					moved = append(moved[:0], rest[:j]...)
+					moved = append(moved, segment...)
+					moved = append(moved, rest[j:]...)
+
+					if l := tourLength(g, moved); l != -1 && l < bestLength {
+						copy(best, moved)
+						bestLength = l
+						improved = true
+					}
+				}
+			}
+		}
+	}
+
+	return &Tour{
+		Length: bestLength,
+		Order:  best,
+	}
+}
@@ -0,0 +1,103 @@
+package tsp
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeuristics(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		g := randomGraph(seed, 9, 60)
+
+		optimal, err := (&HeldKarp{Workers: 1}).Solve(context.Background(), g)
+		assert.NoError(t, err)
+
+		for name, construct := range map[string]func(g *Graph) *Tour{
+			"NearestNeighbour": NearestNeighbour,
+			"GreedyEdge":       GreedyEdge,
+		} {
+			t.Run(fmt.Sprintf("%s-%d", name, seed), func(t *testing.T) {
+				tour := construct(g)
+				if optimal.Order == nil {
+					assert.Nil(t, tour)
+
+					return
+				} else if tour == nil {
+					// Heuristics can miss existing tours.
+					return
+				}
+
+				assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+				assert.True(t, tour.Length >= optimal.Length)
+
+				improved := Improve(g, tour)
+				assert.Equal(t, improved.Length, tourLength(g, improved.Order))
+				assert.True(t, improved.Length <= tour.Length)
+				assert.True(t, improved.Length >= optimal.Length)
+			})
+		}
+	}
+}
+
+func TestReadTour(t *testing.T) {
+	g, err := ReadGraph("../graphs/01-original.graph")
+	assert.NoError(t, err)
+
+	directory, err := ioutil.TempDir("", "tour")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+
+	for content, expected := range map[string]*Tour{
+		"0->3->1->2->0\n": {Length: 15, Order: []int{0, 3, 1, 2}},
+		"3 1 2 0":         {Length: 15, Order: []int{3, 1, 2, 0}},
+	} {
+		file := filepath.Join(directory, "tour")
+		assert.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+
+		tour, err := ReadTour(g, file)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, tour)
+	}
+
+	file := filepath.Join(directory, "tour")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("0->1->1->2"), 0644))
+	_, err = ReadTour(g, file)
+	assert.Error(t, err)
+}
+
+func TestSolveWarmStart(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		g := randomGraph(seed, 9, 70)
+
+		expected, err := (&Sequential{}).Solve(context.Background(), g)
+		assert.NoError(t, err)
+
+		for _, h := range Heuristics {
+			warmStart, err := HeuristicWarmStart(h, true)
+			assert.NoError(t, err)
+
+			for name, s := range map[string]Solver{
+				"Sequential": &Sequential{WarmStart: warmStart},
+				"Parallel":   &Parallel{WarmStart: warmStart, Workers: 4},
+			} {
+				t.Run(fmt.Sprintf("%s-%s-%d", name, h, seed), func(t *testing.T) {
+					tour, err := s.Solve(context.Background(), g)
+					assert.NoError(t, err)
+
+					assert.True(t, tour.Optimal)
+					assert.Equal(t, expected.Length, tour.Length)
+					if tour.Order != nil {
+						assert.Equal(t, 0, tour.Order[0])
+						assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+					}
+				})
+			}
+		}
+	}
+}
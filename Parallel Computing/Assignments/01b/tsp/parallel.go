@@ -0,0 +1,875 @@
+package tsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+const (
+	// stealBackoffMin holds the initial time an idle worker waits before it looks again for paths to steal.
+	stealBackoffMin = time.Microsecond
+	// stealBackoffMax holds the maximum time an idle worker waits before it looks again for paths to steal.
+	stealBackoffMax = time.Millisecond
+)
+
+// Parallel solves graphs with a depth-first search distributed over multiple goroutines.
+// The first levels of the search tree are distributed with a shared queue, afterwards every worker searches its own stack and idle workers steal the shallowest paths of the stacks of busy workers.
+type Parallel struct {
+	// Workers holds the number of worker goroutines. If it is zero, GOMAXPROCS workers are used.
+	Workers int
+	// SplitDepth holds the number of nodes the paths of the shared queue must have before they are handed out to the workers. Paths are also handed out if the queue is too full to take the next expansion.
+	// If it is zero, the queue is only expanded as long as it holds a single path.
+	SplitDepth int
+	// QueueCapacity holds the number of paths the shared queue can hold, which bounds the frontier that is distributed over the workers. The queue only allocates paths when it needs them. If it is zero, the number of nodes times the number of workers is used.
+	QueueCapacity int
+	// Progress is called for every improved tour if it is not nil.
+	Progress ProgressFunc
+	// Bound holds the lower bound which is used to prune paths. If it is empty, BoundNone is used.
+	Bound Bound
+	// WarmStart is used to find an initial tour as the initial bound of the search if it is not nil.
+	WarmStart WarmStart
+	// CheckpointFile holds the file the state of the search is written to periodically and when the search ends, if it is not empty.
+	CheckpointFile string
+	// CheckpointInterval holds the time between two checkpoints. If it is zero, a checkpoint is written every minute. It must not be negative.
+	CheckpointInterval time.Duration
+	// Resume holds the checkpoint of an earlier search of the same graph which is continued if it is not nil.
+	Resume *Checkpoint
+}
+
+// parallelSearch holds the state of one parallel search.
+type parallelSearch struct {
+	graph *Graph
+	// out holds the outgoing edges of every node of the graph.
+	out [][]int
+
+	queue   *pathQueue
+	stop    stopFlag
+	workers []*worker
+
+	// winner holds the best cyclic path of all workers. Its length is read by all workers on every expansion to prune against it.
+	winner *incumbent
+	// progressLock serializes the progress reports of improving workers, so improved paths are reported in order. It is only taken if progress is requested.
+	progressLock sync.Mutex
+
+	// err holds the first error of a worker which stopped the search. It is guarded by the queue lock.
+	err error
+
+	// idle holds the number of workers which have no paths and look for paths to steal. If all workers are idle the search is done. It must be accessed atomically.
+	idle int32
+
+	// pause is read locked by every running worker. It is locked to pause all workers at points where all their paths are in the queue or in their stack.
+	pause sync.RWMutex
+	// pauseRequested is set if the workers should let the pause lock be taken at their next safe point. It must be accessed atomically.
+	pauseRequested int32
+
+	bound      Bound
+	splitDepth int
+	initial    *Path
+	progress   ProgressFunc
+	start      time.Time
+	// searchStart holds the time the workers have been started after the preallocation of their data.
+	searchStart time.Time
+
+	checkpointFile     string
+	checkpointInterval time.Duration
+	resume             *Checkpoint
+}
+
+// pathQueue holds a first-in-first-out queue of paths. Its ring of preallocated paths grows on demand until it reaches the capacity of the queue.
+// Paths of the queue are expanded by workers without holding the lock of the queue, only removing a path and adding its children is done under the lock.
+type pathQueue struct {
+	sync.Mutex
+	// Ready is signaled with the lock of the queue whenever a worker has added the children of an expanded path.
+	Ready *sync.Cond
+
+	Graph    *Graph
+	Items    []*Path
+	Capacity int
+	Current  int
+	Length   int
+
+	// Expanding holds the number of paths which are expanded right now, their children are added to the queue afterwards.
+	Expanding int
+	// Reserved holds the number of slots of the queue which are reserved for the children of the paths which are expanded right now.
+	Reserved int
+}
+
+func newPathQueue(g *Graph, capacity int) *pathQueue {
+	q := &pathQueue{
+		Graph:    g,
+		Capacity: capacity,
+	}
+	q.Ready = sync.NewCond(&q.Mutex)
+
+	return q
+}
+
+// addQueue takes the given path and adds it to the queue. It returns an error if the queue is full.
+func (q *pathQueue) addQueue(path *Path) error {
+	if q.Length == q.Capacity {
+		return fmt.Errorf("queue is full with %d paths", q.Capacity)
+	}
+	if q.Length == len(q.Items) {
+		q.grow()
+	}
+
+	CopyPath(path, q.Items[(q.Current+q.Length)%len(q.Items)])
+	q.Length++
+
+	return nil
+}
+
+// removeQueue removes and returns the current path of the queue. It returns an error if the queue is empty.
+func (q *pathQueue) removeQueue(path *Path) error {
+	if q.Length == 0 {
+		return errors.New("queue is empty")
+	}
+
+	CopyPath(q.Items[q.Current], path)
+	q.Current++
+	q.Current %= len(q.Items) // Take care of the overrun.
+	q.Length--
+
+	if q.Length == 0 {
+		// If the queue is empty, just reset it.
+		q.Current = 0
+	}
+
+	return nil
+}
+
+// grow doubles the number of preallocated paths of the queue without exceeding its capacity.
+func (q *pathQueue) grow() {
+	size := 2 * len(q.Items)
+	if size == 0 {
+		size = q.Graph.NumberOfNodes
+	}
+	if size > q.Capacity {
+		size = q.Capacity
+	}
+
+	items := make([]*Path, size)
+	for i := 0; i < size; i++ {
+		if i < q.Length {
+			items[i] = q.Items[(q.Current+i)%len(q.Items)]
+		} else {
+			items[i] = NewPath(q.Graph)
+		}
+	}
+
+	q.Items = items
+	q.Current = 0
+}
+
+// incumbent holds the best cyclic path of a search. Its length is lowered with compare-and-swap and its path is published as an immutable copy which is swapped atomically, so improving workers do not wait for each other and readers never block.
+// The published path can lag behind the length while a worker is improving the incumbent, but once all improvements are done it is the path with the published length.
+type incumbent struct {
+	// length holds the length of the best cyclic path, noTour means that there is none. It must be accessed atomically.
+	length int64
+	// path holds the best cyclic path as *Path, which must not be changed once it is stored. It must be accessed atomically.
+	path unsafe.Pointer
+}
+
+// newIncumbent returns an incumbent without a cyclic path.
+func newIncumbent() *incumbent {
+	return &incumbent{
+		length: noTour,
+	}
+}
+
+// Length returns the length of the best cyclic path, noTour means that there is none.
+func (i *incumbent) Length() int {
+	return int(atomic.LoadInt64(&i.length))
+}
+
+// Path returns the best cyclic path, nil means that there is none. The returned path must not be changed.
+func (i *incumbent) Path() *Path {
+	return (*Path)(atomic.LoadPointer(&i.path))
+}
+
+// improve publishes a copy of the given cyclic path of the given graph if it is shorter than the best cyclic path. It returns if the path has been published.
+func (i *incumbent) improve(g *Graph, p *Path) bool {
+	for {
+		length := atomic.LoadInt64(&i.length)
+		if length != noTour && int64(p.Length) >= length {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&i.length, length, int64(p.Length)) {
+			break
+		}
+	}
+
+	published := NewPath(g)
+	CopyPath(p, published)
+
+	// A shorter path may have been published in the meantime, which must not be replaced.
+	for {
+		current := atomic.LoadPointer(&i.path)
+		if current != nil && (*Path)(current).Length <= published.Length {
+			return true
+		}
+		if atomic.CompareAndSwapPointer(&i.path, current, unsafe.Pointer(published)) {
+			return true
+		}
+	}
+}
+
+// pathStack holds the paths of a worker. The worker pushes and pops paths at the top, other workers steal paths from the bottom which hold the shallowest paths and therefore the biggest subtrees.
+// Only stealing takes the lock of the stack. A thief never takes the top path, so the worker only has to lock the stack if it pops the bottom path, which a thief might steal at the same time, or if it moves the paths of the stack.
+type pathStack struct {
+	sync.Mutex
+
+	Items []*Path
+	// Bottom holds the index of the bottom path. It is only changed under the lock of the stack and must be accessed atomically.
+	Bottom int64
+	// Length holds the index after the top path. It is only changed by the worker of the stack and must be accessed atomically.
+	Length int64
+}
+
+// pushStack takes the given path and adds it to the stack. Only the worker of the stack may push paths.
+func (s *pathStack) pushStack(path *Path) {
+	if s.Length == int64(len(s.Items)) {
+		s.compact()
+	}
+
+	CopyPath(path, s.Items[s.Length])
+	atomic.StoreInt64(&s.Length, s.Length+1)
+}
+
+// compact moves the remaining paths of the stack to the front to reuse the slots of the stolen paths.
+func (s *pathStack) compact() {
+	s.Lock()
+	if bottom := s.Bottom; bottom != 0 {
+		for i := int64(0); i < s.Length-bottom; i++ {
+			s.Items[i], s.Items[bottom+i] = s.Items[bottom+i], s.Items[i]
+		}
+		atomic.StoreInt64(&s.Length, s.Length-bottom)
+		atomic.StoreInt64(&s.Bottom, 0)
+	}
+	s.Unlock()
+}
+
+// popStack removes the top path of the stack and copies it into the given path. It returns false if the stack is empty. Only the worker of the stack may pop paths.
+func (s *pathStack) popStack(path *Path) bool {
+	top := s.Length - 1
+	atomic.StoreInt64(&s.Length, top)
+
+	if top <= atomic.LoadInt64(&s.Bottom) {
+		return s.popBottom(path)
+	}
+
+	// Thieves never take the top path, so the path is ours.
+	CopyPath(s.Items[top], path)
+
+	return true
+}
+
+// popBottom does the same as popStack if the top path of the stack is the bottom path, which a thief might have stolen in the meantime. The top path must already be removed from the length of the stack.
+func (s *pathStack) popBottom(path *Path) bool {
+	top := s.Length
+
+	s.Lock()
+	popped := top >= s.Bottom
+	if popped {
+		CopyPath(s.Items[top], path)
+	}
+	// The stack is empty now, so start again at the front.
+	atomic.StoreInt64(&s.Bottom, 0)
+	atomic.StoreInt64(&s.Length, 0)
+	s.Unlock()
+
+	return popped
+}
+
+// stealable returns if another worker can steal a path from the stack. The top path is left to the worker itself, stealing it would just pass around the work.
+// Without holding the lock of the stack the result is only a hint.
+func (s *pathStack) stealable() bool {
+	return atomic.LoadInt64(&s.Length)-atomic.LoadInt64(&s.Bottom) > 1
+}
+
+// stealStack removes the bottom path of the stack and copies it into the given path. The lock of the stack must be held and the stack must be stealable.
+func (s *pathStack) stealStack(path *Path) {
+	CopyPath(s.Items[s.Bottom], path)
+	atomic.StoreInt64(&s.Bottom, s.Bottom+1)
+}
+
+type worker struct {
+	Stack *pathStack
+	// Frontier holds the children of the path the worker expands for the shared queue until they are added to the queue.
+	Frontier []*Path
+	// Winner holds a copy of the shared winner which is only refreshed when the worker completes a cyclic path shorter than the copy. Paths are pruned against the best length of the search instead, which is never longer.
+	Winner *Path
+	Path   *Path
+	Bound  bounder
+
+	// Stats holds the statistics of the worker, which are only accessed by the worker itself.
+	Stats Stats
+	// Published holds the statistics of the worker as of its last call to publishStats. Its counters must be accessed atomically, since progress reports and checkpoints read them while the worker is running.
+	Published Stats
+}
+
+// statsPublishInterval holds the number of expanded paths after which a worker publishes its statistics.
+const statsPublishInterval = 1024
+
+// publishStats publishes the statistics of the worker.
+func (w *worker) publishStats() {
+	w.Published.store(&w.Stats)
+}
+
+func newWorker(g *Graph, bound bounder) *worker {
+	maxPaths := maxStackPaths(g.NumberOfNodes)
+
+	w := &worker{
+		Stack: &pathStack{
+			Items:  make([]*Path, maxPaths),
+			Length: 0,
+		},
+		Frontier: make([]*Path, g.NumberOfNodes-1),
+		Winner:   NewPath(g),
+		Path:     NewPath(g),
+		Bound:    bound,
+	}
+	for i := 0; i < maxPaths; i++ {
+		w.Stack.Items[i] = NewPath(g)
+	}
+	for i := range w.Frontier {
+		w.Frontier[i] = NewPath(g)
+	}
+
+	return w
+}
+
+// Solve tries to find the shortest cyclic path visiting all nodes in the given graph.
+func (s *Parallel) Solve(ctx context.Context, g *Graph) (*Tour, error) {
+	start := time.Now()
+
+	// Every search starts at node 0.
+	if g.NumberOfNodes < 1 {
+		return nil, fmt.Errorf("graph must have at least 1 node but has %d", g.NumberOfNodes)
+	}
+
+	workerLength := s.Workers
+	if workerLength <= 0 {
+		workerLength = runtime.GOMAXPROCS(-1)
+	}
+
+	if s.SplitDepth < 0 {
+		return nil, fmt.Errorf("split depth must not be negative but is %d", s.SplitDepth)
+	}
+	queueCapacity := s.QueueCapacity
+	if queueCapacity == 0 {
+		queueCapacity = g.NumberOfNodes * workerLength
+	} else if queueCapacity < g.NumberOfNodes {
+		// The queue must at least be able to take all paths of the first expansion.
+		return nil, fmt.Errorf("queue capacity must be at least %d but is %d", g.NumberOfNodes, queueCapacity)
+	}
+
+	checkpointInterval := s.CheckpointInterval
+	if checkpointInterval == 0 {
+		checkpointInterval = time.Minute
+	} else if checkpointInterval < 0 {
+		return nil, fmt.Errorf("checkpoint interval must not be negative but is %s", checkpointInterval)
+	}
+
+	// Validate the bound before any work is done.
+	if _, err := s.Bound.newBounder(g); err != nil {
+		return nil, err
+	}
+
+	initial, err := warmStartPath(g, s.WarmStart)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.Resume != nil && s.Resume.Graph != graphFingerprint(g) {
+		return nil, errors.New("checkpoint has been written for a different graph")
+	}
+
+	search := &parallelSearch{
+		graph:              g,
+		out:                g.outgoing(),
+		bound:              s.Bound,
+		splitDepth:         s.SplitDepth,
+		initial:            initial,
+		progress:           s.Progress,
+		start:              time.Now(),
+		checkpointFile:     s.CheckpointFile,
+		checkpointInterval: checkpointInterval,
+		resume:             s.Resume,
+	}
+
+	release := search.stop.watch(ctx)
+	winner, err := search.solve(workerLength, queueCapacity)
+	end := time.Now()
+	release()
+	if err != nil {
+		return nil, err
+	}
+
+	optimal := !search.stop.isSet()
+
+	var tour *Tour
+	if winner == nil {
+		tour = &Tour{
+			Optimal: optimal,
+		}
+	} else {
+		tour = newTour(winner, optimal)
+	}
+	tour.Stats = search.stats()
+	tour.Stats.Workers = make([]Stats, len(search.workers))
+	for i, w := range search.workers {
+		tour.Stats.Workers[i] = w.Published.load()
+	}
+	tour.Setup = search.searchStart.Sub(start)
+	tour.Search = end.Sub(search.searchStart)
+
+	return tour, nil
+}
+
+// solve tries to find the shortest cyclic path visiting all nodes in the graph of the search using the given number of workers and a queue with the given capacity.
+// It returns nil if there is no cyclic path.
+func (s *parallelSearch) solve(workerLength int, queueCapacity int) (*Path, error) {
+	g := s.graph
+
+	s.queue = newPathQueue(g, queueCapacity)
+	s.winner = newIncumbent()
+	if s.initial != nil {
+		s.winner.improve(g, s.initial)
+	}
+
+	// Preallocate worker's data.
+	s.workers = make([]*worker, workerLength)
+	for i := 0; i < workerLength; i++ {
+		// Every worker needs its own bounder since bounders hold scratch space.
+		bound, _ := s.bound.newBounder(g)
+		s.workers[i] = newWorker(g, bound)
+	}
+
+	if s.resume != nil {
+		if err := s.restoreCheckpoint(s.resume); err != nil {
+			return nil, err
+		}
+	} else {
+		// Init the queue by adding the first path.
+		p := NewPath(g)
+		p.AddNode(g, 0)
+		if err := s.queue.addQueue(p); err != nil {
+			return nil, err
+		}
+	}
+
+	if winner := s.winner.Path(); winner != nil {
+		for _, w := range s.workers {
+			CopyPath(winner, w.Winner)
+		}
+		s.reportProgress(winner)
+	}
+
+	finished := make(chan struct{})
+	checkpointErr := make(chan error, 1)
+	if s.checkpointFile != "" {
+		go func() {
+			checkpointErr <- s.writeCheckpoints(finished)
+		}()
+	}
+
+	s.searchStart = time.Now()
+
+	var wg sync.WaitGroup
+	wg.Add(workerLength)
+
+	for i := 0; i < workerLength; i++ {
+		go func(i int) {
+			s.solveWorker(i)
+
+			wg.Done()
+		}(i)
+	}
+
+	wg.Wait()
+
+	if s.checkpointFile != "" {
+		close(finished)
+		if err := <-checkpointErr; err != nil {
+			return nil, err
+		}
+
+		// All workers are done, so their paths are all in the queue and their stacks.
+		if err := s.checkpoint().Write(s.checkpointFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	// All workers are done, so the published path is the best one.
+	return s.winner.Path(), nil
+}
+
+// expandQueue tries to expand the queue, if it succeeds it returns true and w.Path holds the next path for the worker.
+// The queue must be locked and is locked again when expandQueue returns, but it is unlocked while the worker expands a path so that other workers can take paths in the meantime.
+func (s *parallelSearch) expandQueue(w *worker) (bool, error) {
+	g := s.graph
+	q := s.queue
+
+	for {
+		// Wait for the children of the paths other workers are expanding, since they might refill the queue.
+		for q.Length == 0 && q.Expanding != 0 {
+			q.Ready.Wait()
+		}
+
+		// If the queue is empty we are done.
+		if q.Length == 0 {
+			return false, nil
+		}
+
+		if err := q.removeQueue(w.Path); err != nil {
+			return false, err
+		}
+
+		if s.handOut(w.Path) {
+			return true, nil
+		}
+
+		// Reserve room for all children of the path, so the expansion of another worker cannot take it.
+		q.Expanding++
+		q.Reserved += g.NumberOfNodes - 1
+		q.Unlock()
+
+		children := s.expandFrontier(w)
+
+		s.lockQueue(w)
+		q.Expanding--
+		q.Reserved -= g.NumberOfNodes - 1
+
+		var err error
+		for _, child := range w.Frontier[:children] {
+			if err = q.addQueue(child); err != nil {
+				break
+			}
+			w.Stats.Pushed++
+		}
+		q.Ready.Broadcast()
+
+		if err != nil {
+			return false, err
+		}
+
+		// Check again, if we should exit or if we should expand.
+	}
+}
+
+// expandFrontier expands the path of the given worker into the frontier of the worker and returns the number of children that can still be shorter than the current best path. The queue does not need to be locked.
+func (s *parallelSearch) expandFrontier(w *worker) int {
+	g := s.graph
+
+	w.Stats.Expanded++
+	bestLength := s.bestLength()
+
+	children := 0
+	for _, i := range s.out[w.Path.Order[w.Path.OrderLength-1]] {
+		if !w.Path.PathExists(g, i) {
+			continue
+		}
+
+		w.Path.AddNode(g, i)
+
+		if w.Path.OrderLength == g.NumberOfNodes {
+			s.evaluateCompletedPath(w)
+
+			break
+		}
+
+		// If the path is not done, keep it for the queue but only proceed with paths that can still be shorter than the current best path.
+		if promising(w.Bound, w.Path, bestLength) {
+			CopyPath(w.Path, w.Frontier[children])
+			children++
+		} else {
+			s.countPruned(w, bestLength)
+		}
+
+		w.Path.RemoveLastNode(g)
+	}
+
+	return children
+}
+
+// handOut returns if the given path which has just been removed from the queue should be handed out to a worker instead of being expanded.
+func (s *parallelSearch) handOut(p *Path) bool {
+	if s.splitDepth == 0 {
+		// If we had more than one path in the queue, we continue with the removed one for the local stack.
+		return s.queue.Length != 0
+	}
+
+	// Only expand paths if the queue can take all of their children next to the children of the paths which are expanded right now.
+	return p.OrderLength >= s.splitDepth || s.queue.Capacity-s.queue.Length-s.queue.Reserved < s.graph.NumberOfNodes-1
+}
+
+// solveWorker tries to find the shortest cyclic path visiting all nodes in the graph of the search using the worker with the given index, while updating the shared winner.
+func (s *parallelSearch) solveWorker(index int) {
+	g := s.graph
+	w := s.workers[index]
+
+	s.pause.RLock()
+	defer s.pause.RUnlock()
+	defer w.publishStats()
+
+	// The fields of the worker are kept in variables, since the loop below is the hot path of the search.
+	p := w.Path
+	stack := w.Stack
+
+	// The stack of the worker is only filled at the start if the search is resumed.
+	for {
+		// Idle workers can steal paths from the stack in between the expansions.
+		for {
+			s.safePoint(w)
+
+			// The remaining paths are kept for the final checkpoint if the search was stopped.
+			if s.stop.isSet() || !stack.popStack(p) {
+				break
+			}
+
+			w.Stats.Expanded++
+			if w.Stats.Expanded%statsPublishInterval == 0 {
+				w.publishStats()
+			}
+			bestLength := s.bestLength()
+
+			// Expand the current path and push everything on the stack if needed.
+			for _, i := range s.out[p.Order[p.OrderLength-1]] {
+				if !p.PathExists(g, i) {
+					continue
+				}
+
+				p.AddNode(g, i)
+
+				if p.OrderLength == g.NumberOfNodes {
+					s.evaluateCompletedPath(w)
+
+					break
+				}
+
+				// If the path is not done, put the path back on the stack but only proceed with paths that can still be shorter than the current best path.
+				if promising(w.Bound, p, bestLength) {
+					stack.pushStack(p)
+					w.Stats.Pushed++
+				} else {
+					s.countPruned(w, bestLength)
+				}
+
+				p.RemoveLastNode(g)
+			}
+		}
+
+		if !s.nextPath(index) {
+			return
+		}
+
+		w.Stack.pushStack(w.Path)
+	}
+}
+
+// nextPath looks for the next path of the worker with the given index, first in the queue and then in the stacks of the other workers. If it succeeds it returns true and the path of the worker holds the next path.
+// It returns false if the search was stopped or if all workers are idle, since then there are no paths left.
+func (s *parallelSearch) nextPath(index int) bool {
+	w := s.workers[index]
+
+	if s.stop.isSet() {
+		return false
+	}
+
+	s.lockQueue(w)
+	expanded, err := s.expandQueue(w)
+	if err != nil && s.err == nil {
+		// Stop all workers, the search cannot be completed.
+		s.err = err
+		s.stop.set()
+	}
+	s.queue.Unlock()
+
+	if err != nil {
+		return false
+	} else if expanded {
+		return true
+	}
+
+	// The queue is never filled again once it is empty, so steal paths from the other workers until they are all idle too.
+	// Only busy workers have paths in their stacks, so an idle worker must leave the idle count before it can steal a path.
+	atomic.AddInt32(&s.idle, 1)
+
+	// Back off while there is nothing to steal, so idle workers do not take away the processors of busy workers.
+	backoff := stealBackoffMin
+	for {
+		s.safePoint(w)
+
+		if s.stop.isSet() || atomic.LoadInt32(&s.idle) == int32(len(s.workers)) {
+			return false
+		}
+
+		if s.stealable(index) {
+			atomic.AddInt32(&s.idle, -1)
+
+			if s.steal(index) {
+				return true
+			}
+
+			atomic.AddInt32(&s.idle, 1)
+		}
+
+		time.Sleep(backoff)
+		if backoff < stealBackoffMax {
+			backoff *= 2
+		}
+	}
+}
+
+// safePoint lets a requested pause happen. The given worker must not hold any path outside of the queue and its stack.
+func (s *parallelSearch) safePoint(w *worker) {
+	if atomic.LoadInt32(&s.pauseRequested) == 1 {
+		s.pauseWorker(w)
+	}
+}
+
+// pauseWorker lets the requested pause happen for the given worker. Its statistics are published before, since the pause is requested for a checkpoint.
+func (s *parallelSearch) pauseWorker(w *worker) {
+	w.publishStats()
+	s.pause.RUnlock()
+	s.pause.RLock()
+}
+
+// stealable returns if there is a worker other than the given one which has a path that can be stolen.
+func (s *parallelSearch) stealable(index int) bool {
+	for i := 1; i < len(s.workers); i++ {
+		if s.workers[(index+i)%len(s.workers)].Stack.stealable() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// steal tries to steal the shallowest path of another worker for the worker with the given index. The workers following the given one are tried first to spread the stealing over all workers.
+func (s *parallelSearch) steal(index int) bool {
+	w := s.workers[index]
+
+	for i := 1; i < len(s.workers); i++ {
+		victim := s.workers[(index+i)%len(s.workers)]
+
+		victim.Stack.Lock()
+		stealable := victim.Stack.stealable()
+		if stealable {
+			victim.Stack.stealStack(w.Path)
+		}
+		victim.Stack.Unlock()
+
+		if stealable {
+			w.Stats.Steals++
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// evaluateCompletedPath completes the path of the worker, which holds all nodes, if the last node has an edge back to the start node and evaluates if it is the new winner.
+func (s *parallelSearch) evaluateCompletedPath(w *worker) {
+	g := s.graph
+
+	// We know that the last edge of a path is always the same node, so do this last step right now and therefore drop all other paths for this node.
+	if !w.Path.PathExists(g, w.Path.Order[0]) {
+		return
+	}
+
+	w.Path.AddNode(g, w.Path.Order[0])
+	w.Stats.Completed++
+
+	// Record if the current path is the best one.
+	if w.Winner.OrderLength == 0 || w.Path.Length < w.Winner.Length {
+		if s.winner.improve(g, w.Path) {
+			w.Stats.Improved++
+			CopyPath(w.Path, w.Winner)
+			s.reportImprovement(w)
+		} else if winner := s.winner.Path(); winner != nil {
+			CopyPath(winner, w.Winner)
+		}
+	}
+}
+
+// bestLength returns the length of the best cyclic path of all workers, noTour means that there is none.
+func (s *parallelSearch) bestLength() int {
+	return s.winner.Length()
+}
+
+// countPruned counts the path of the given worker as pruned, since it cannot lead to a cyclic path which is shorter than a cyclic path with the given length of the best cyclic path of all workers.
+func (s *parallelSearch) countPruned(w *worker, bestLength int) {
+	w.Stats.Pruned++
+	// Count the paths the worker would have kept with only its own winner as bound.
+	if length := winnerLength(w.Winner); length != bestLength && promising(w.Bound, w.Path, length) {
+		w.Stats.StalePruned++
+	}
+}
+
+// reportImprovement reports the path of the given worker which has just improved the winner if progress is requested. The path is not reported if another worker has improved the winner even further in the meantime.
+func (s *parallelSearch) reportImprovement(w *worker) {
+	if s.progress == nil {
+		return
+	}
+
+	// The report holds the statistics of all workers, which must include the improvement.
+	w.publishStats()
+
+	s.progressLock.Lock()
+	if w.Path.Length == s.winner.Length() {
+		s.reportProgress(w.Path)
+	}
+	s.progressLock.Unlock()
+}
+
+// reportProgress reports the given improved path if progress is requested.
+func (s *parallelSearch) reportProgress(winner *Path) {
+	if s.progress == nil {
+		return
+	}
+
+	stats := s.stats()
+
+	s.progress(Progress{
+		Tour:     newTour(winner, false),
+		Elapsed:  time.Since(s.start),
+		Expanded: stats.Expanded,
+		Stats:    stats,
+	})
+}
+
+// stats returns the statistics of all workers so far including the statistics of the resumed search.
+func (s *parallelSearch) stats() Stats {
+	var stats Stats
+	if s.resume != nil {
+		stats.add(&s.resume.Stats)
+	}
+	for _, w := range s.workers {
+		worker := w.Published.load()
+		stats.add(&worker)
+	}
+
+	return stats
+}
+
+// lockQueue locks the queue for the given worker and records how long the worker had to wait for the lock.
+func (s *parallelSearch) lockQueue(w *worker) {
+	start := time.Now()
+	s.queue.Lock()
+
+	w.Stats.QueueLocks++
+	w.Stats.QueueWait += time.Since(start)
+}
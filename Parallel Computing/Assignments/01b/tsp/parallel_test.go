@@ -0,0 +1,271 @@
+package tsp
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathStackSteal(t *testing.T) {
+	g := NewGraph(4)
+	s := &pathStack{
+		Items: []*Path{NewPath(g), NewPath(g), NewPath(g)},
+	}
+	p := NewPath(g)
+
+	for i := 1; i <= 3; i++ {
+		p.Length = i
+		s.pushStack(p)
+	}
+
+	// The bottom holds the oldest path.
+	assert.True(t, s.stealable())
+	s.stealStack(p)
+	assert.Equal(t, 1, p.Length)
+	s.stealStack(p)
+	assert.Equal(t, 2, p.Length)
+
+	// The last path is left to the worker.
+	assert.False(t, s.stealable())
+
+	// A full stack reuses the slots of the stolen paths.
+	p.Length = 4
+	s.pushStack(p)
+	p.Length = 5
+	s.pushStack(p)
+	assert.Equal(t, int64(0), s.Bottom)
+	assert.Equal(t, int64(3), s.Length)
+
+	for _, expected := range []int{5, 4, 3} {
+		assert.True(t, s.popStack(p))
+		assert.Equal(t, expected, p.Length)
+	}
+	assert.Equal(t, int64(0), s.Length)
+	assert.False(t, s.popStack(p))
+	assert.Equal(t, int64(0), s.Length)
+}
+
+func TestPathStackStealConcurrently(t *testing.T) {
+	g := NewGraph(4)
+	s := &pathStack{
+		Items: make([]*Path, 8),
+	}
+	for i := range s.Items {
+		s.Items[i] = NewPath(g)
+	}
+
+	// Every pushed path must be taken exactly once, either by the worker or by a thief.
+	const paths = 10000
+	taken := make([]int32, paths+1)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			defer wg.Done()
+
+			p := NewPath(g)
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				s.Lock()
+				if s.stealable() {
+					s.stealStack(p)
+					atomic.AddInt32(&taken[p.Length], 1)
+				}
+				s.Unlock()
+
+				runtime.Gosched()
+			}
+		}()
+	}
+
+	p := NewPath(g)
+	for i := 1; i <= paths; i++ {
+		p.Length = i
+		s.pushStack(p)
+		// Let the thieves run even if there is only a single processor.
+		runtime.Gosched()
+
+		// Keep a few paths on the stack to give the thieves something to steal without letting the stack overflow, but empty it from time to time to race with the thieves for the bottom path.
+		for (s.Length > 4 || i%64 == 0) && s.popStack(p) {
+			atomic.AddInt32(&taken[p.Length], 1)
+		}
+	}
+	for s.popStack(p) {
+		atomic.AddInt32(&taken[p.Length], 1)
+	}
+	close(done)
+	wg.Wait()
+
+	for i := 1; i <= paths; i++ {
+		if taken[i] != 1 {
+			assert.Failf(t, "path taken wrongly", "path %d has been taken %d times", i, taken[i])
+
+			break
+		}
+	}
+}
+
+func TestParallelWorkerStats(t *testing.T) {
+	g, err := ReadGraph("../graphs/02-20-nodes-fraction-65.graph")
+	assert.NoError(t, err)
+
+	tour, err := (&Parallel{Workers: 4, Bound: BoundReduced}).Solve(context.Background(), g)
+	assert.NoError(t, err)
+	assert.Equal(t, 352, tour.Length)
+
+	assert.Len(t, tour.Stats.Workers, 4)
+
+	var sum Stats
+	for _, w := range tour.Stats.Workers {
+		sum.Expanded += w.Expanded
+		sum.Pruned += w.Pruned
+		sum.Steals += w.Steals
+	}
+	assert.Equal(t, tour.Stats.Expanded, sum.Expanded)
+	assert.Equal(t, tour.Stats.Pruned, sum.Pruned)
+	assert.Equal(t, tour.Stats.Steals, sum.Steals)
+}
+
+func TestParallelSharedIncumbent(t *testing.T) {
+	g, err := ReadGraph("../graphs/02-20-nodes-fraction-65.graph")
+	assert.NoError(t, err)
+
+	cold, err := (&Sequential{Bound: BoundReduced}).Solve(context.Background(), g)
+	assert.NoError(t, err)
+	assert.Equal(t, 352, cold.Length)
+
+	// With the optimal tour as the incumbent from the start the incumbent never changes, so the searched paths do not depend on the scheduling of the workers.
+	warmStart := func(g *Graph) (*Tour, error) {
+		return cold, nil
+	}
+	warm, err := (&Sequential{Bound: BoundReduced, WarmStart: warmStart}).Solve(context.Background(), g)
+	assert.NoError(t, err)
+	assert.Equal(t, 352, warm.Length)
+	// Pruning against the optimal length from the start cuts the search tree closer to its root.
+	assert.True(t, warm.Stats.Expanded < cold.Stats.Expanded)
+	assert.True(t, warm.Stats.Pruned > 0)
+
+	for _, workers := range []int{1, 2, 4, 8} {
+		t.Run(fmt.Sprintf("%d", workers), func(t *testing.T) {
+			tour, err := (&Parallel{Workers: workers, Bound: BoundReduced, WarmStart: warmStart}).Solve(context.Background(), g)
+			assert.NoError(t, err)
+
+			assert.Equal(t, 352, tour.Length)
+			assert.True(t, tour.Optimal)
+			// Every worker must prune against the seeded incumbent, so the parallel search expands and prunes exactly the paths the sequential search does.
+			assert.Equal(t, warm.Stats.Expanded, tour.Stats.Expanded)
+			assert.Equal(t, warm.Stats.Pruned, tour.Stats.Pruned)
+		})
+	}
+}
+
+func TestParallelSplitDepth(t *testing.T) {
+	g, err := ReadGraph("../graphs/08-18-nodes-fraction-90.graph")
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		splitDepth    int
+		queueCapacity int
+	}{
+		{0, 0},
+		{1, 0},
+		{3, 0},
+		{3, 18},
+		{5, 1000},
+	} {
+		t.Run(fmt.Sprintf("%d-%d", tc.splitDepth, tc.queueCapacity), func(t *testing.T) {
+			tour, err := (&Parallel{Workers: 4, SplitDepth: tc.splitDepth, QueueCapacity: tc.queueCapacity, Bound: BoundReduced}).Solve(context.Background(), g)
+			assert.NoError(t, err)
+
+			assert.Equal(t, 209, tour.Length)
+			assert.True(t, tour.Optimal)
+		})
+	}
+
+	_, err = (&Parallel{QueueCapacity: 17}).Solve(context.Background(), g)
+	assert.EqualError(t, err, "queue capacity must be at least 18 but is 17")
+
+	_, err = (&Parallel{SplitDepth: -1}).Solve(context.Background(), g)
+	assert.EqualError(t, err, "split depth must not be negative but is -1")
+}
+
+func TestPathQueue(t *testing.T) {
+	g := NewGraph(2)
+	q := newPathQueue(g, 5)
+	p := NewPath(g)
+
+	assert.EqualError(t, q.removeQueue(p), "queue is empty")
+
+	// Wrap around the ring before it grows, so the order must be kept while growing.
+	for i := 1; i <= 2; i++ {
+		p.Length = i
+		assert.NoError(t, q.addQueue(p))
+	}
+	assert.NoError(t, q.removeQueue(p))
+	assert.Equal(t, 1, p.Length)
+	for i := 3; i <= 6; i++ {
+		p.Length = i
+		assert.NoError(t, q.addQueue(p))
+	}
+	assert.Len(t, q.Items, 5)
+	assert.EqualError(t, q.addQueue(p), "queue is full with 5 paths")
+
+	for _, expected := range []int{2, 3, 4, 5, 6} {
+		assert.NoError(t, q.removeQueue(p))
+		assert.Equal(t, expected, p.Length)
+	}
+	assert.Equal(t, 0, q.Length)
+}
+
+func TestIncumbent(t *testing.T) {
+	g := NewGraph(4)
+	i := newIncumbent()
+	assert.Equal(t, noTour, i.Length())
+	assert.Nil(t, i.Path())
+
+	p := NewPath(g)
+	p.Length = 0
+	assert.True(t, i.improve(g, p))
+	assert.False(t, i.improve(g, p))
+
+	// Concurrent improvements end with the shortest path published.
+	i = newIncumbent()
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+
+			p := NewPath(g)
+			for length := 96 + worker; length >= worker; length -= 8 {
+				p.Length = length
+				i.improve(g, p)
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 0, i.Length())
+	assert.Equal(t, 0, i.Path().Length)
+
+	// The published path is a copy.
+	p.Length = -5
+	assert.Equal(t, 0, i.Path().Length)
+}
+
+// BenchmarkParallelOneWorker measures the overhead of the parallel search compared to BenchmarkSequential.
+func BenchmarkParallelOneWorker(b *testing.B) {
+	benchmarkSolver(b, &Parallel{Workers: 1})
+}
@@ -0,0 +1,153 @@
+package tsp
+
+import (
+	"fmt"
+)
+
+// Path holds a path through a graph.
+type Path struct {
+	Length      int
+	Visited     []bool
+	Order       []int
+	OrderLength int
+}
+
+// NewPath returns an empty path for the given graph.
+func NewPath(g *Graph) *Path {
+	return &Path{
+		Length:      0,
+		Visited:     make([]bool, g.NumberOfNodes),
+		Order:       make([]int, g.NumberOfNodes),
+		OrderLength: 0,
+	}
+}
+
+// maxStackPaths returns the number of paths a depth-first search stack for a graph with the given number of nodes must be able to hold. Expanding a path of length l pushes at most n-l children, which adds up to n*(n-1)/2, but the start path needs a place even if the graph has a single node.
+func maxStackPaths(numberOfNodes int) int {
+	maxPaths := numberOfNodes * (numberOfNodes - 1) / 2
+	if maxPaths < 1 {
+		maxPaths = 1
+	}
+
+	return maxPaths
+}
+
+// CopyPath copies the given path into the other given path.
+func CopyPath(from *Path, to *Path) {
+	to.Length = from.Length
+	copy(to.Visited, from.Visited)
+	copy(to.Order, from.Order)
+	to.OrderLength = from.OrderLength
+}
+
+// Print prints the path to STDOUT.
+func (p *Path) Print() {
+	numberOfNodes := len(p.Order)
+
+	fmt.Printf("%d=", p.Length)
+	for i := 0; i < p.OrderLength && i < numberOfNodes; i++ {
+		fmt.Printf("%d->", p.Order[i])
+	}
+	if p.OrderLength > numberOfNodes {
+		fmt.Printf("%d\n", p.Order[0])
+	} else {
+		fmt.Println()
+	}
+}
+
+// PathExists takes the given node index and returns if the node can be added to the path.
+func (p *Path) PathExists(g *Graph, node int) bool {
+	// If the path is empty, we can add the node right away.
+	if p.OrderLength == 0 {
+		return true
+	}
+
+	// If there is no edge, there is no path.
+	if !g.HasEdge(p.Order[p.OrderLength-1], node) {
+		return false
+	}
+
+	if p.Visited[node] {
+		// Exit if we do not have all nodes in our path.
+		if p.OrderLength != g.NumberOfNodes {
+			return false
+		}
+		// Exit if the start node is not equal to the end node.
+		if p.Order[0] != node {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AddNode takes the given node index and adds the node to the path.
+func (p *Path) AddNode(g *Graph, node int) {
+	// If the path is empty, we can add the node right away.
+	if p.OrderLength == 0 {
+		p.Visited[node] = true
+		p.Order[0] = node
+		p.OrderLength++
+
+		return
+	}
+
+	edgeLength := g.Matrix[p.Order[p.OrderLength-1]][node]
+
+	// Do not record the last edge.
+	if !p.Visited[node] {
+		p.Visited[node] = true
+		p.Order[p.OrderLength] = node
+	}
+	p.OrderLength++
+
+	p.Length += edgeLength
+}
+
+// RemoveLastNode removes the last inserted node.
+func (p *Path) RemoveLastNode(g *Graph) {
+	if p.OrderLength == 0 {
+		// This should never happen.
+
+		return
+	}
+
+	node := p.Order[p.OrderLength-1]
+
+	p.Visited[node] = false
+	p.Length -= g.Matrix[p.Order[p.OrderLength-2]][node]
+	p.Order[p.OrderLength-1] = 0 // This is unnecessary.
+	p.OrderLength--
+}
+
+// AddNodeIfPathExist takes the given node index and tries to add the node to the path.
+// Returns -1 if the node cannot be added to the path.
+func (p *Path) AddNodeIfPathExist(g *Graph, node int) int {
+	if !p.PathExists(g, node) {
+		return -1
+	}
+
+	p.AddNode(g, node)
+
+	return p.Length
+}
+
+// PathFromOrder returns the path which visits the given nodes in the given order. It returns an error if the nodes do not form a path in the graph.
+// A cyclic path is completed by repeating its start node as its last node.
+func PathFromOrder(g *Graph, order []int) (*Path, error) {
+	if len(order) > g.NumberOfNodes+1 {
+		return nil, fmt.Errorf("path has %d nodes but the graph only has %d", len(order), g.NumberOfNodes)
+	}
+
+	p := NewPath(g)
+	for _, node := range order {
+		if node < 0 || node >= g.NumberOfNodes {
+			return nil, fmt.Errorf("node %d is not part of the graph", node)
+		}
+		if p.AddNodeIfPathExist(g, node) == -1 {
+			return nil, fmt.Errorf("node %d cannot be added to the path %v", node, order)
+		}
+	}
+
+	return p, nil
+}
@@ -1,4 +1,4 @@
-package main
+package tsp
 
 import (
 	"testing"
@@ -7,53 +7,54 @@ import (
 )
 
 func TestAddNodeIfPathExist(t *testing.T) {
-	assert.NoError(t, readGraph("../graphs/01-original.graph"))
+	g, err := ReadGraph("../graphs/01-original.graph")
+	assert.NoError(t, err)
 
-	p := newPath()
+	p := NewPath(g)
 
 	// Add the initial node 0.
-	assert.Equal(t, 0, addNodeIfPathExist(p, 0))
+	assert.Equal(t, 0, p.AddNodeIfPathExist(g, 0))
 	assert.Equal(t, 0, p.Length)
 	assert.Equal(t, []bool{true, false, false, false}, p.Visited)
 	assert.Equal(t, []int{0, 0, 0, 0}, p.Order)
 	assert.Equal(t, 1, p.OrderLength)
 
 	// Add node 0 again.
-	assert.Equal(t, -1, addNodeIfPathExist(p, 0))
+	assert.Equal(t, -1, p.AddNodeIfPathExist(g, 0))
 	assert.Equal(t, 0, p.Length)
 	assert.Equal(t, []bool{true, false, false, false}, p.Visited)
 	assert.Equal(t, []int{0, 0, 0, 0}, p.Order)
 	assert.Equal(t, 1, p.OrderLength)
 
 	// Add node 1.
-	assert.Equal(t, 1, addNodeIfPathExist(p, 1))
+	assert.Equal(t, 1, p.AddNodeIfPathExist(g, 1))
 	assert.Equal(t, 1, p.Length)
 	assert.Equal(t, []bool{true, true, false, false}, p.Visited)
 	assert.Equal(t, []int{0, 1, 0, 0}, p.Order)
 	assert.Equal(t, 2, p.OrderLength)
 
 	// Add node 0 again.
-	assert.Equal(t, -1, addNodeIfPathExist(p, 0))
+	assert.Equal(t, -1, p.AddNodeIfPathExist(g, 0))
 
 	// Add node 3.
-	assert.Equal(t, 7, addNodeIfPathExist(p, 3))
+	assert.Equal(t, 7, p.AddNodeIfPathExist(g, 3))
 	assert.Equal(t, 7, p.Length)
 	assert.Equal(t, []bool{true, true, false, true}, p.Visited)
 	assert.Equal(t, []int{0, 1, 3, 0}, p.Order)
 	assert.Equal(t, 3, p.OrderLength)
 
 	// Add node 0 again.
-	assert.Equal(t, -1, addNodeIfPathExist(p, 0))
+	assert.Equal(t, -1, p.AddNodeIfPathExist(g, 0))
 
 	// Add node 2.
-	assert.Equal(t, 19, addNodeIfPathExist(p, 2))
+	assert.Equal(t, 19, p.AddNodeIfPathExist(g, 2))
 	assert.Equal(t, 19, p.Length)
 	assert.Equal(t, []bool{true, true, true, true}, p.Visited)
 	assert.Equal(t, []int{0, 1, 3, 2}, p.Order)
 	assert.Equal(t, 4, p.OrderLength)
 
 	// Finish with adding node 0.
-	assert.Equal(t, 20, addNodeIfPathExist(p, 0))
+	assert.Equal(t, 20, p.AddNodeIfPathExist(g, 0))
 	assert.Equal(t, 20, p.Length)
 	assert.Equal(t, []bool{true, true, true, true}, p.Visited)
 	assert.Equal(t, []int{0, 1, 3, 2}, p.Order)
@@ -61,26 +62,27 @@ func TestAddNodeIfPathExist(t *testing.T) {
 }
 
 func TestRemoveLastNode(t *testing.T) {
-	assert.NoError(t, readGraph("../graphs/01-original.graph"))
+	g, err := ReadGraph("../graphs/01-original.graph")
+	assert.NoError(t, err)
 
-	p := newPath()
+	p := NewPath(g)
 
 	// Add the initial node 0.
-	assert.Equal(t, 0, addNodeIfPathExist(p, 0))
+	assert.Equal(t, 0, p.AddNodeIfPathExist(g, 0))
 	assert.Equal(t, 0, p.Length)
 	assert.Equal(t, []bool{true, false, false, false}, p.Visited)
 	assert.Equal(t, []int{0, 0, 0, 0}, p.Order)
 	assert.Equal(t, 1, p.OrderLength)
 
 	// Add node 1.
-	assert.Equal(t, 1, addNodeIfPathExist(p, 1))
+	assert.Equal(t, 1, p.AddNodeIfPathExist(g, 1))
 	assert.Equal(t, 1, p.Length)
 	assert.Equal(t, []bool{true, true, false, false}, p.Visited)
 	assert.Equal(t, []int{0, 1, 0, 0}, p.Order)
 	assert.Equal(t, 2, p.OrderLength)
 
 	// Remove node 1.
-	removeLastNode(p)
+	p.RemoveLastNode(g)
 	assert.Equal(t, 0, p.Length)
 	assert.Equal(t, []bool{true, false, false, false}, p.Visited)
 	assert.Equal(t, []int{0, 0, 0, 0}, p.Order)
@@ -88,15 +90,16 @@ func TestRemoveLastNode(t *testing.T) {
 }
 
 func TestCopyPath(t *testing.T) {
-	assert.NoError(t, readGraph("../graphs/01-original.graph"))
+	g, err := ReadGraph("../graphs/01-original.graph")
+	assert.NoError(t, err)
 
-	p0 := newPath()
+	p0 := NewPath(g)
 
-	assert.NotEqual(t, -1, addNodeIfPathExist(p0, 0))
+	assert.NotEqual(t, -1, p0.AddNodeIfPathExist(g, 0))
 
-	p1 := newPath()
-	copyPath(p0, p1)
-	assert.NotEqual(t, -1, addNodeIfPathExist(p1, 1))
+	p1 := NewPath(g)
+	CopyPath(p0, p1)
+	assert.NotEqual(t, -1, p1.AddNodeIfPathExist(g, 1))
 
 	assert.Equal(
 		t,
@@ -119,11 +122,3 @@ func TestCopyPath(t *testing.T) {
 		p1,
 	)
 }
-
-func TestSolve(t *testing.T) {
-	assert.NoError(t, readGraph("../graphs/01-original.graph"))
-
-	p := solve()
-	assert.Equal(t, 15, p.Length)
-	assert.Equal(t, []int{0, 3, 1, 2}, p.Order)
-}
@@ -0,0 +1,346 @@
+package tsp
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Sequential solves graphs with a depth-first search on a single goroutine.
+type Sequential struct {
+	// Progress is called for every improved tour if it is not nil.
+	Progress ProgressFunc
+	// Bound holds the lower bound which is used to prune paths. If it is empty, BoundNone is used.
+	Bound Bound
+	// WarmStart is used to find an initial tour as the initial bound of the search if it is not nil.
+	WarmStart WarmStart
+	// Bitset holds the paths of the search as bitsets with fixed arrays instead of slices if the graph has no more than 64 nodes, which saves the copying of slices.
+	Bitset bool
+	// Undo searches with a single path which is extended and shortened again instead of a stack of paths, so only a position in the outgoing edges of every node of the path is kept. It takes precedence over Bitset.
+	Undo bool
+}
+
+// sequentialSearch holds the state of one sequential search.
+type sequentialSearch struct {
+	graph *Graph
+	// out holds the outgoing edges of every node of the graph.
+	out [][]int
+
+	stack       []*Path
+	stackLength int
+	stop        stopFlag
+	bound       bounder
+	initial     *Path
+	// incumbent holds the length of the best cyclic path found by other searches if it is not nil, noTour means that there is none. It must be accessed atomically.
+	incumbent *int64
+
+	progress ProgressFunc
+	start    time.Time
+	// searchStart holds the time the search has been started after its preallocation.
+	searchStart time.Time
+	stats       Stats
+}
+
+// Solve tries to find the shortest cyclic path visiting all nodes in the given graph.
+func (s *Sequential) Solve(ctx context.Context, g *Graph) (*Tour, error) {
+	start := time.Now()
+
+	// Every search starts at node 0.
+	if g.NumberOfNodes < 1 {
+		return nil, fmt.Errorf("graph must have at least 1 node but has %d", g.NumberOfNodes)
+	}
+
+	bound, err := s.Bound.newBounder(g)
+	if err != nil {
+		return nil, err
+	}
+
+	initial, err := warmStartPath(g, s.WarmStart)
+	if err != nil {
+		return nil, err
+	}
+
+	search := &sequentialSearch{
+		graph:    g,
+		out:      g.outgoing(),
+		bound:    bound,
+		initial:  initial,
+		progress: s.Progress,
+		start:    time.Now(),
+	}
+
+	release := search.stop.watch(ctx)
+	var winner *Path
+	if s.Undo {
+		winner = search.solveUndo()
+	} else if s.Bitset && g.NumberOfNodes <= maxBitPathNodes {
+		winner = search.solveBitset()
+	} else {
+		winner = search.solve()
+	}
+	end := time.Now()
+	release()
+
+	optimal := !search.stop.isSet()
+
+	var tour *Tour
+	if winner == nil {
+		tour = &Tour{
+			Optimal: optimal,
+		}
+	} else {
+		tour = newTour(winner, optimal)
+	}
+	tour.Stats = search.stats
+	tour.Setup = search.searchStart.Sub(start)
+	tour.Search = end.Sub(search.searchStart)
+
+	return tour, nil
+}
+
+// solve tries to find the shortest cyclic path visiting all nodes in the graph of the search.
+func (s *sequentialSearch) solve() *Path {
+	p := NewPath(s.graph)
+	p.AddNode(s.graph, 0)
+
+	return s.solveFrom(p)
+}
+
+// solveBitset does the same as solve but holds the paths as bitPath values. The graph of the search must not have more than maxBitPathNodes nodes.
+func (s *sequentialSearch) solveBitset() *Path {
+	p := NewPath(s.graph)
+	p.AddNode(s.graph, 0)
+
+	return s.solveBitsetFrom(p)
+}
+
+// allocateStack preallocates the stack of the search.
+func (s *sequentialSearch) allocateStack() {
+	maxPaths := maxStackPaths(s.graph.NumberOfNodes)
+	s.stack = make([]*Path, maxPaths)
+	for i := 0; i < maxPaths; i++ {
+		s.stack[i] = NewPath(s.graph)
+	}
+}
+
+// solveFrom tries to find the shortest cyclic path beginning with the given path. It returns nil if there is no such path which is shorter than the incumbent of the search.
+// The stack is only allocated by the first call, so a search can be reused for multiple paths of its graph.
+func (s *sequentialSearch) solveFrom(root *Path) *Path {
+	g := s.graph
+
+	if s.stack == nil {
+		s.allocateStack()
+	}
+	s.stackLength = 0
+	s.searchStart = time.Now()
+
+	// Init the stack by adding the first path.
+	p := NewPath(g)
+	s.pushPath(root)
+
+	winner := NewPath(g)
+	if s.initial != nil {
+		CopyPath(s.initial, winner)
+		s.reportProgress(winner)
+	}
+
+	for s.stackLength != 0 {
+		// Stop with the best path found so far if the search was cancelled.
+		if s.stop.isSet() {
+			break
+		}
+
+		s.popPath(p)
+		s.stats.Expanded++
+
+		bestLength := s.bestLength(winner)
+
+		for _, i := range s.out[p.Order[p.OrderLength-1]] {
+			if !p.PathExists(g, i) {
+				continue
+			}
+
+			p.AddNode(g, i)
+
+			// If the path is at the last node
+			if p.OrderLength == g.NumberOfNodes {
+				// We know that the last edge of a path is always the same node, so do this last step right now and therefore drop all other paths for this node.
+
+				if p.PathExists(g, p.Order[0]) {
+					p.AddNode(g, p.Order[0])
+					s.stats.Completed++
+
+					// Record if the current path is the best one.
+					if bestLength == noTour || p.Length < bestLength {
+						s.stats.Improved++
+						bestLength = p.Length
+						CopyPath(p, winner)
+						s.reportProgress(winner)
+					}
+				}
+
+				break
+			}
+
+			// If the path is not done, put the path back on the stack but only proceed with paths that can still be shorter than the current best path.
+			if promising(s.bound, p, bestLength) {
+				s.pushPath(p)
+				s.stats.Pushed++
+			} else {
+				s.stats.Pruned++
+			}
+
+			p.RemoveLastNode(g)
+		}
+	}
+
+	if winner.OrderLength == 0 {
+		// There is no winner
+		return nil
+	}
+
+	return winner
+}
+
+// solveUndo does the same as solve but backtracks a single path instead of copying paths to and from a stack.
+func (s *sequentialSearch) solveUndo() *Path {
+	p := NewPath(s.graph)
+	p.AddNode(s.graph, 0)
+
+	return s.solveUndoFrom(p)
+}
+
+// solveUndoFrom does the same search as solveFrom but extends the given path node by node and removes the nodes again when their subtrees are done. The children of a path are visited in the order solveFrom pops them from its stack.
+func (s *sequentialSearch) solveUndoFrom(root *Path) *Path {
+	g := s.graph
+
+	p := NewPath(g)
+	CopyPath(root, p)
+
+	// next holds for every length of the path how many outgoing edges of the last node of the path are left, they are taken from the back.
+	next := make([]int, g.NumberOfNodes+1)
+	s.searchStart = time.Now()
+	next[p.OrderLength] = len(s.out[p.Order[p.OrderLength-1]])
+	s.stats.Expanded++
+
+	winner := NewPath(g)
+	if s.initial != nil {
+		CopyPath(s.initial, winner)
+		s.reportProgress(winner)
+	}
+
+	bestLength := s.bestLength(winner)
+
+	for {
+		length := p.OrderLength
+		if next[length] == 0 {
+			// All children of the path are done, so go back to its parent.
+			if length == root.OrderLength {
+				break
+			}
+
+			p.RemoveLastNode(g)
+
+			continue
+		}
+		next[length]--
+
+		i := s.out[p.Order[length-1]][next[length]]
+		if !p.PathExists(g, i) {
+			continue
+		}
+
+		p.AddNode(g, i)
+
+		// If the path is at the last node
+		if p.OrderLength == g.NumberOfNodes {
+			// The last edge of the path always leads to the start node, it is not added to the path since it could not be removed again.
+			if p.PathExists(g, p.Order[0]) {
+				s.stats.Completed++
+
+				// Record if the current path is the best one.
+				if cycleLength := p.Length + g.Matrix[i][p.Order[0]]; bestLength == noTour || cycleLength < bestLength {
+					s.stats.Improved++
+					bestLength = cycleLength
+					CopyPath(p, winner)
+					winner.AddNode(g, winner.Order[0])
+					s.reportProgress(winner)
+				}
+			}
+
+			// There is only one node left for the parent, so its children are done too.
+			next[length] = 0
+			p.RemoveLastNode(g)
+
+			continue
+		}
+
+		// If the path is not done, expand it right away but only if it can still be shorter than the current best path.
+		if !promising(s.bound, p, bestLength) {
+			s.stats.Pruned++
+			p.RemoveLastNode(g)
+
+			continue
+		}
+		s.stats.Pushed++
+
+		// Stop with the best path found so far if the search was cancelled.
+		if s.stop.isSet() {
+			break
+		}
+
+		next[p.OrderLength] = len(s.out[i])
+		s.stats.Expanded++
+
+		bestLength = s.bestLength(winner)
+	}
+
+	if winner.OrderLength == 0 {
+		// There is no winner
+		return nil
+	}
+
+	return winner
+}
+
+// bestLength returns the length of the given winner or the incumbent of the search, whichever is shorter. noTour means that there is no cyclic path yet.
+func (s *sequentialSearch) bestLength(winner *Path) int {
+	length := winnerLength(winner)
+	if s.incumbent == nil {
+		return length
+	}
+
+	incumbent := int(atomic.LoadInt64(s.incumbent))
+	if length == noTour || (incumbent != noTour && incumbent < length) {
+		return incumbent
+	}
+
+	return length
+}
+
+// reportProgress reports the given improved path if progress is requested.
+func (s *sequentialSearch) reportProgress(winner *Path) {
+	if s.progress == nil {
+		return
+	}
+
+	s.progress(Progress{
+		Tour:     newTour(winner, false),
+		Elapsed:  time.Since(s.start),
+		Expanded: s.stats.Expanded,
+		Stats:    s.stats,
+	})
+}
+
+// pushPath takes the given path and adds it to the working stack.
+func (s *sequentialSearch) pushPath(path *Path) {
+	CopyPath(path, s.stack[s.stackLength])
+	s.stackLength++
+}
+
+// popPath removes and returns the current path of the stack.
+func (s *sequentialSearch) popPath(path *Path) {
+	CopyPath(s.stack[s.stackLength-1], path)
+	s.stackLength--
+}
@@ -0,0 +1,212 @@
+package tsp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Solver finds the shortest cyclic path visiting all nodes of a graph.
+// Implementations hold no state between calls to Solve, so a solver can be used to solve multiple graphs concurrently.
+type Solver interface {
+	// Solve tries to find the shortest cyclic path visiting all nodes of the given graph.
+	// If the given context is done before the search is finished, the search is stopped and the best tour found so far is returned as not proven optimal.
+	Solve(ctx context.Context, g *Graph) (*Tour, error)
+}
+
+// Tour holds the result of solving a graph.
+type Tour struct {
+	// Length holds the length of the cyclic path.
+	Length int
+	// Order holds the nodes of the cyclic path beginning with the start node, the return to the start node is not included. It is nil if there is no cyclic path.
+	Order []int
+	// Optimal is true if the search was completed, which proves that there is no shorter cyclic path. If the search was stopped early it is false.
+	Optimal bool
+	// Stats holds the statistics of the search.
+	Stats Stats
+	// Setup holds the time the solver took to prepare the search, e.g. to preallocate the stacks of its workers. It is zero if the solver does not measure it.
+	Setup time.Duration
+	// Search holds the time the solver took to search after its setup. It is zero if the solver does not measure it.
+	Search time.Duration
+}
+
+// Stats holds statistics of a search.
+type Stats struct {
+	// Expanded holds the number of paths that have been expanded.
+	Expanded int64
+	// Pushed holds the number of paths that have been put on a stack or into a queue by an expansion to be expanded later.
+	Pushed int64
+	// Pruned holds the number of paths that have been dropped because they cannot lead to a shorter cyclic path.
+	Pruned int64
+	// StalePruned holds the number of pruned paths which would have been pushed if they had only been bounded by the best cyclic path the searching worker found itself instead of the best cyclic path of all workers. Each of them is the root of a subtree a stale bound would have cost.
+	StalePruned int64
+	// Completed holds the number of cyclic paths that have been found.
+	Completed int64
+	// Improved holds the number of cyclic paths that have been shorter than the best cyclic path known to the searching worker.
+	Improved int64
+	// Steals holds the number of paths that idle workers have stolen from busy workers.
+	Steals int64
+	// QueueLocks holds the number of times the shared queue of a parallel search has been locked by a worker.
+	QueueLocks int64
+	// QueueWait holds the time workers have waited for the lock of the shared queue.
+	QueueWait time.Duration
+	// Workers holds the statistics of every worker of a parallel search, the statistics of a worker hold no workers themselves.
+	Workers []Stats
+}
+
+// add adds the counters of the given statistics to the statistics, the statistics of the workers are not added.
+func (s *Stats) add(o *Stats) {
+	s.Expanded += o.Expanded
+	s.Pushed += o.Pushed
+	s.Pruned += o.Pruned
+	s.StalePruned += o.StalePruned
+	s.Completed += o.Completed
+	s.Improved += o.Improved
+	s.Steals += o.Steals
+	s.QueueLocks += o.QueueLocks
+	s.QueueWait += o.QueueWait
+}
+
+// load returns a copy of the counters of the statistics which are updated concurrently with atomic operations.
+func (s *Stats) load() Stats {
+	return Stats{
+		Expanded:    atomic.LoadInt64(&s.Expanded),
+		Pushed:      atomic.LoadInt64(&s.Pushed),
+		Pruned:      atomic.LoadInt64(&s.Pruned),
+		StalePruned: atomic.LoadInt64(&s.StalePruned),
+		Completed:   atomic.LoadInt64(&s.Completed),
+		Improved:    atomic.LoadInt64(&s.Improved),
+		Steals:      atomic.LoadInt64(&s.Steals),
+		QueueLocks:  atomic.LoadInt64(&s.QueueLocks),
+		QueueWait:   time.Duration(atomic.LoadInt64((*int64)(&s.QueueWait))),
+	}
+}
+
+// store sets the counters of the statistics, which are read concurrently with atomic operations, to the counters of the given statistics.
+func (s *Stats) store(o *Stats) {
+	atomic.StoreInt64(&s.Expanded, o.Expanded)
+	atomic.StoreInt64(&s.Pushed, o.Pushed)
+	atomic.StoreInt64(&s.Pruned, o.Pruned)
+	atomic.StoreInt64(&s.StalePruned, o.StalePruned)
+	atomic.StoreInt64(&s.Completed, o.Completed)
+	atomic.StoreInt64(&s.Improved, o.Improved)
+	atomic.StoreInt64(&s.Steals, o.Steals)
+	atomic.StoreInt64(&s.QueueLocks, o.QueueLocks)
+	atomic.StoreInt64((*int64)(&s.QueueWait), int64(o.QueueWait))
+}
+
+// newTour returns a tour for the given completed path.
+func newTour(p *Path, optimal bool) *Tour {
+	order := make([]int, len(p.Order))
+	copy(order, p.Order)
+
+	return &Tour{
+		Length:  p.Length,
+		Order:   order,
+		Optimal: optimal,
+	}
+}
+
+// noTour is used as length of the best cyclic path as long as there is none, since a cyclic path can have a length of zero.
+const noTour = -1
+
+// winnerLength returns the length of the given best cyclic path or noTour if the path is empty.
+func winnerLength(p *Path) int {
+	if p.OrderLength == 0 {
+		return noTour
+	}
+
+	return p.Length
+}
+
+// Progress holds an improved tour which has been found during a search.
+type Progress struct {
+	// Tour holds the improved tour, it is not proven optimal.
+	Tour *Tour
+	// Elapsed holds the time since the search has been started.
+	Elapsed time.Duration
+	// Expanded holds the number of paths that have been expanded so far.
+	Expanded int64
+	// Stats holds the statistics of the search so far without the statistics of the workers.
+	Stats Stats
+}
+
+// ProgressFunc is called by a solver for every improved tour. Calls are made in the order of improvement and never concurrently, but the function should return fast as it blocks the search.
+type ProgressFunc func(p Progress)
+
+// ProgressChannel returns a ProgressFunc which sends every improved tour to the given channel.
+func ProgressChannel(c chan<- Progress) ProgressFunc {
+	return func(p Progress) {
+		c <- p
+	}
+}
+
+// String returns the cyclic path of the tour in the form "0->3->1->2->0".
+func (t *Tour) String() string {
+	if t.Order == nil {
+		return ""
+	}
+
+	var b bytes.Buffer
+	for _, node := range t.Order {
+		fmt.Fprintf(&b, "%d->", node)
+	}
+	fmt.Fprintf(&b, "%d", t.Order[0])
+
+	return b.String()
+}
+
+// LabeledString returns the tour like String but uses the labels of the nodes of the given graph.
+func (t *Tour) LabeledString(g *Graph) string {
+	if t.Order == nil {
+		return ""
+	}
+
+	var b bytes.Buffer
+	for _, node := range t.Order {
+		fmt.Fprintf(&b, "%s->", g.Label(node))
+	}
+	fmt.Fprintf(&b, "%s", g.Label(t.Order[0]))
+
+	return b.String()
+}
+
+// stopFlag is set as soon as the context of a search is done. Checking the flag is cheap enough to do it for every expanded path.
+type stopFlag struct {
+	stopped int32
+}
+
+// watch sets the flag as soon as the given context is done. The returned function must be called to release the watcher when the search is finished.
+func (f *stopFlag) watch(ctx context.Context) func() {
+	if ctx.Err() != nil {
+		f.set()
+
+		return func() {}
+	}
+
+	finished := make(chan struct{})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			f.set()
+		case <-finished:
+		}
+	}()
+
+	return func() {
+		close(finished)
+	}
+}
+
+// set stops the search.
+func (f *stopFlag) set() {
+	atomic.StoreInt32(&f.stopped, 1)
+}
+
+// isSet returns if the search should stop.
+func (f *stopFlag) isSet() bool {
+	return atomic.LoadInt32(&f.stopped) == 1
+}
@@ -0,0 +1,363 @@
+package tsp
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// randomGraph returns a graph with the given number of nodes where the given fraction in percent of the edges exist.
+func randomGraph(seed int64, numberOfNodes int, fraction int) *Graph {
+	g := NewGraph(numberOfNodes)
+	r := rand.New(rand.NewSource(seed))
+
+	for y := 0; y < numberOfNodes; y++ {
+		for x := 0; x < numberOfNodes; x++ {
+			if x != y && r.Intn(100) < fraction {
+				g.SetEdge(y, x, r.Intn(100)+1)
+			}
+		}
+	}
+
+	return g
+}
+
+func solvers() map[string]Solver {
+	return map[string]Solver{
+		"Sequential":        &Sequential{},
+		"Sequential-Bitset": &Sequential{Bitset: true},
+		"Sequential-Undo":   &Sequential{Undo: true},
+		"Parallel-1":        &Parallel{Workers: 1},
+		"Parallel-4":        &Parallel{Workers: 4},
+		"Parallel-16":       &Parallel{Workers: 16},
+	}
+}
+
+func TestSolve(t *testing.T) {
+	g, err := ReadGraph("../graphs/01-original.graph")
+	assert.NoError(t, err)
+
+	for name, s := range solvers() {
+		t.Run(name, func(t *testing.T) {
+			tour, err := s.Solve(context.Background(), g)
+			assert.NoError(t, err)
+
+			assert.Equal(t, 15, tour.Length)
+			assert.Equal(t, []int{0, 3, 1, 2}, tour.Order)
+			assert.True(t, tour.Optimal)
+		})
+	}
+}
+
+func TestSolveTimeout(t *testing.T) {
+	g, err := ReadGraph("../graphs/05-25-nodes-fraction-35.graph")
+	assert.NoError(t, err)
+
+	for name, s := range solvers() {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			start := time.Now()
+			tour, err := s.Solve(ctx, g)
+			assert.NoError(t, err)
+
+			assert.True(t, time.Since(start) < 5*time.Second)
+			assert.False(t, tour.Optimal)
+			if tour.Order != nil {
+				assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+			}
+		})
+	}
+}
+
+func TestSolveProgress(t *testing.T) {
+	g := randomGraph(1, 10, 80)
+
+	for name, s := range map[string]Solver{
+		"Sequential": &Sequential{},
+		"Parallel":   &Parallel{Workers: 4},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var progress []Progress
+			switch s := s.(type) {
+			case *Sequential:
+				s.Progress = func(p Progress) {
+					progress = append(progress, p)
+				}
+			case *Parallel:
+				s.Progress = func(p Progress) {
+					progress = append(progress, p)
+				}
+			}
+
+			tour, err := s.Solve(context.Background(), g)
+			assert.NoError(t, err)
+
+			assert.NotEmpty(t, progress)
+			for i, p := range progress {
+				assert.False(t, p.Tour.Optimal)
+				assert.Equal(t, p.Tour.Length, tourLength(g, p.Tour.Order))
+				if i > 0 {
+					assert.True(t, p.Tour.Length < progress[i-1].Tour.Length)
+					assert.True(t, p.Expanded >= progress[i-1].Expanded)
+				}
+			}
+			assert.Equal(t, tour.Length, progress[len(progress)-1].Tour.Length)
+		})
+	}
+}
+
+func TestSolveStats(t *testing.T) {
+	g, err := ReadGraph("../graphs/08-18-nodes-fraction-90.graph")
+	assert.NoError(t, err)
+
+	for name, s := range map[string]Solver{
+		"Sequential":      &Sequential{Bound: BoundReduced},
+		"Sequential-Undo": &Sequential{Bound: BoundReduced, Undo: true},
+		"Parallel":        &Parallel{Bound: BoundReduced, Workers: 4},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var progress []Progress
+			switch s := s.(type) {
+			case *Sequential:
+				s.Progress = func(p Progress) {
+					progress = append(progress, p)
+				}
+			case *Parallel:
+				s.Progress = func(p Progress) {
+					progress = append(progress, p)
+				}
+			}
+
+			tour, err := s.Solve(context.Background(), g)
+			assert.NoError(t, err)
+			assert.Equal(t, 209, tour.Length)
+			assert.True(t, tour.Setup > 0)
+			assert.True(t, tour.Search > 0)
+
+			stats := tour.Stats
+			// Every pushed path and the first path have been expanded.
+			assert.Equal(t, stats.Pushed+1, stats.Expanded)
+			assert.True(t, stats.Pruned > 0)
+			assert.True(t, stats.Pruned >= stats.StalePruned)
+			assert.True(t, stats.Completed >= stats.Improved)
+			assert.True(t, stats.Improved >= int64(len(progress)))
+
+			var workers Stats
+			for i := range stats.Workers {
+				workers.add(&stats.Workers[i])
+			}
+			if name == "Parallel" {
+				assert.Len(t, stats.Workers, 4)
+				assert.True(t, stats.QueueLocks >= 4)
+				stats.Workers = nil
+				assert.Equal(t, stats, workers)
+			}
+
+			for i, p := range progress {
+				assert.Equal(t, p.Expanded, p.Stats.Expanded)
+				if i > 0 {
+					assert.True(t, p.Stats.Completed >= progress[i-1].Stats.Completed)
+					assert.True(t, p.Stats.Improved > progress[i-1].Stats.Improved)
+				}
+			}
+		})
+	}
+}
+
+func TestSolveRandomGraphs(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		g := randomGraph(seed, 9, 70)
+
+		expected, err := (&Sequential{}).Solve(context.Background(), g)
+		assert.NoError(t, err)
+
+		for name, s := range solvers() {
+			t.Run(fmt.Sprintf("%s-%d", name, seed), func(t *testing.T) {
+				tour, err := s.Solve(context.Background(), g)
+				assert.NoError(t, err)
+
+				if expected.Order == nil {
+					assert.Nil(t, tour.Order)
+				} else {
+					assert.Equal(t, expected.Length, tour.Length)
+					assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+				}
+			})
+		}
+	}
+}
+
+func TestSolveDisconnectedGraphs(t *testing.T) {
+	for name, g := range map[string]*Graph{
+		// Node 3 has no incoming edges.
+		"Unreachable": NewGraphFromMatrix([][]int{
+			{0, 1, 2, 0},
+			{1, 0, 3, 0},
+			{2, 3, 0, 0},
+			{4, 5, 6, 0},
+		}),
+		// Node 2 has no outgoing edges.
+		"DeadEnd": NewGraphFromMatrix([][]int{
+			{0, 1, 2, 3},
+			{1, 0, 3, 4},
+			{0, 0, 0, 0},
+			{1, 2, 3, 0},
+		}),
+		// The nodes 0 and 1 are not connected with the nodes 2 and 3.
+		"Components": NewGraphFromMatrix([][]int{
+			{0, 1, 0, 0},
+			{1, 0, 0, 0},
+			{0, 0, 0, 1},
+			{0, 0, 1, 0},
+		}),
+		"NoEdges": NewGraph(6),
+	} {
+		for solverName, s := range solvers() {
+			t.Run(name+"-"+solverName, func(t *testing.T) {
+				tour, err := s.Solve(context.Background(), g)
+				assert.NoError(t, err)
+
+				assert.True(t, tour.Optimal)
+				assert.Equal(t, 0, tour.Length)
+				assert.Nil(t, tour.Order)
+			})
+		}
+	}
+}
+
+func TestSolveSingleNode(t *testing.T) {
+	// A single node has no edge to return to itself, so there is no cyclic path.
+	g := NewGraph(1)
+
+	for _, b := range Bounds {
+		for name, s := range map[string]Solver{
+			"Sequential":        &Sequential{Bound: b},
+			"Sequential-Bitset": &Sequential{Bound: b, Bitset: true},
+			"Sequential-Undo":   &Sequential{Bound: b, Undo: true},
+			"Parallel":          &Parallel{Bound: b, Workers: 4},
+		} {
+			t.Run(fmt.Sprintf("%s-%s", name, b), func(t *testing.T) {
+				tour, err := s.Solve(context.Background(), g)
+				assert.NoError(t, err)
+
+				assert.True(t, tour.Optimal)
+				assert.Nil(t, tour.Order)
+			})
+		}
+	}
+}
+
+func TestSolveEmptyGraph(t *testing.T) {
+	for _, g := range []*Graph{NewGraph(0), NewGraphFromMatrix(nil)} {
+		for name, s := range solvers() {
+			t.Run(name, func(t *testing.T) {
+				_, err := s.Solve(context.Background(), g)
+				assert.EqualError(t, err, "graph must have at least 1 node but has 0")
+			})
+		}
+
+		_, err := (&HeldKarp{}).Solve(context.Background(), g)
+		assert.EqualError(t, err, "graph must have at least 2 nodes but has 0")
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.NoError(t, err)
+		_, err = (&Coordinator{Listener: listener}).Solve(context.Background(), g)
+		assert.EqualError(t, err, "graph must have at least 1 node but has 0")
+	}
+}
+
+func TestSolveZeroLengthEdges(t *testing.T) {
+	// Every cyclic path has a length of zero.
+	zero := NewGraph(5)
+	for y := 0; y < zero.NumberOfNodes; y++ {
+		for x := 0; x < zero.NumberOfNodes; x++ {
+			if x != y {
+				zero.SetEdge(y, x, 0)
+			}
+		}
+	}
+
+	for name, s := range solvers() {
+		t.Run("Zero-"+name, func(t *testing.T) {
+			tour, err := s.Solve(context.Background(), zero)
+			assert.NoError(t, err)
+
+			assert.True(t, tour.Optimal)
+			assert.Equal(t, 0, tour.Length)
+			assert.Equal(t, 0, tourLength(zero, tour.Order))
+		})
+	}
+
+	for seed := int64(0); seed < 10; seed++ {
+		g := NewGraph(8)
+		r := rand.New(rand.NewSource(seed))
+		for y := 0; y < g.NumberOfNodes; y++ {
+			for x := 0; x < g.NumberOfNodes; x++ {
+				if x != y && r.Intn(100) < 70 {
+					g.SetEdge(y, x, r.Intn(3))
+				}
+			}
+		}
+
+		expected, err := (&HeldKarp{Workers: 1}).Solve(context.Background(), g)
+		assert.NoError(t, err)
+
+		for name, s := range solvers() {
+			t.Run(fmt.Sprintf("%s-%d", name, seed), func(t *testing.T) {
+				tour, err := s.Solve(context.Background(), g)
+				assert.NoError(t, err)
+
+				assert.True(t, tour.Optimal)
+				assert.Equal(t, expected.Order == nil, tour.Order == nil)
+				assert.Equal(t, expected.Length, tour.Length)
+				if tour.Order != nil {
+					assert.Equal(t, tour.Length, tourLength(g, tour.Order))
+				}
+			})
+		}
+	}
+}
+
+func TestSolveConcurrently(t *testing.T) {
+	graphs := make([]*Graph, 8)
+	expected := make([]*Tour, len(graphs))
+	for i := range graphs {
+		graphs[i] = randomGraph(int64(i), 9, 90)
+
+		var err error
+		expected[i], err = (&Sequential{}).Solve(context.Background(), graphs[i])
+		assert.NoError(t, err)
+	}
+
+	for name, s := range solvers() {
+		t.Run(name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			wg.Add(len(graphs))
+
+			tours := make([]*Tour, len(graphs))
+			for i := range graphs {
+				go func(i int) {
+					defer wg.Done()
+
+					var err error
+					tours[i], err = s.Solve(context.Background(), graphs[i])
+					assert.NoError(t, err)
+				}(i)
+			}
+
+			wg.Wait()
+
+			for i := range graphs {
+				assert.Equal(t, expected[i].Length, tours[i].Length)
+			}
+		})
+	}
+}
@@ -0,0 +1,414 @@
+package tsp
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// isTSPLIB returns if the given file content is in the TSPLIB format, whose first line holds one of the keywords NAME, TYPE, COMMENT or DIMENSION instead of the number of nodes.
+func isTSPLIB(data []byte) bool {
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(nil, len(data)+1)
+
+	for s.Scan() {
+		text := strings.TrimSpace(s.Text())
+		if text == "" {
+			continue
+		}
+
+		keyword := text
+		if i := strings.Index(text, ":"); i != -1 {
+			keyword = strings.TrimSpace(text[:i])
+		}
+
+		switch strings.ToUpper(keyword) {
+		case "NAME", "TYPE", "COMMENT", "DIMENSION":
+			return true
+		}
+
+		return false
+	}
+
+	return false
+}
+
+// tsplibProblem holds the specification and the data of a TSPLIB file.
+type tsplibProblem struct {
+	typ                string
+	dimension          int
+	edgeWeightType     string
+	edgeWeightFormat   string
+	coordinates        [][]float64
+	edgeWeights        []float64
+	hasEdgeWeights     bool
+	hasNodeCoordinates bool
+}
+
+// readTSPLIB reads in a symmetric (TSP) or asymmetric (ATSP) traveling salesman problem in the TSPLIB format.
+// Edge weights can be given explicitly in any of the TSPLIB matrix formats or as node coordinates of the types EUC_2D, EUC_3D, MAN_2D, MAX_2D, CEIL_2D, GEO and ATT.
+func readTSPLIB(data []byte) (*Graph, error) {
+	p := &tsplibProblem{
+		edgeWeightFormat: "FUNCTION",
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	s.Buffer(nil, len(data)+1)
+
+	line := 0
+	for s.Scan() {
+		line++
+
+		text := strings.TrimSpace(s.Text())
+		if text == "" {
+			continue
+		}
+
+		// Keywords are separated from their values by a colon, sections have no colon.
+		keyword, value := text, ""
+		if i := strings.Index(text, ":"); i != -1 {
+			keyword, value = strings.TrimSpace(text[:i]), strings.TrimSpace(text[i+1:])
+		}
+
+		var err error
+		switch strings.ToUpper(keyword) {
+		case "TYPE":
+			p.typ = strings.ToUpper(value)
+		case "NAME", "COMMENT", "CAPACITY", "NODE_COORD_TYPE", "DISPLAY_DATA_TYPE":
+			// Not needed.
+		case "DIMENSION":
+			p.dimension, err = strconv.Atoi(value)
+			if err == nil && p.dimension < 1 {
+				err = fmt.Errorf("dimension must be positive but is %d", p.dimension)
+			}
+		case "EDGE_WEIGHT_TYPE":
+			p.edgeWeightType = strings.ToUpper(value)
+		case "EDGE_WEIGHT_FORMAT":
+			p.edgeWeightFormat = strings.ToUpper(value)
+		case "NODE_COORD_SECTION":
+			err = p.readNodeCoordinates(s, &line)
+		case "EDGE_WEIGHT_SECTION":
+			err = p.readEdgeWeights(s, &line)
+		case "DISPLAY_DATA_SECTION":
+			err = p.skipLines(s, &line)
+		case "FIXED_EDGES_SECTION", "TOUR_SECTION":
+			err = p.skipUntilTerminator(s, &line)
+		case "EOF":
+			return p.graph()
+		default:
+			err = fmt.Errorf("unknown keyword %q", keyword)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d of TSPLIB file: %v", line, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return p.graph()
+}
+
+// numbers reads in the given number of numbers from the following lines of the scanner.
+func (p *tsplibProblem) numbers(s *bufio.Scanner, line *int, count int) ([]float64, error) {
+	numbers := make([]float64, 0, count)
+	for len(numbers) < count {
+		if !s.Scan() {
+			return nil, fmt.Errorf("expected %d numbers but found only %d", count, len(numbers))
+		}
+		*line++
+
+		for _, field := range strings.Fields(s.Text()) {
+			n, err := strconv.ParseFloat(field, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q", field)
+			}
+
+			numbers = append(numbers, n)
+		}
+	}
+	if len(numbers) != count {
+		return nil, fmt.Errorf("expected %d numbers but found %d", count, len(numbers))
+	}
+
+	return numbers, nil
+}
+
+func (p *tsplibProblem) readNodeCoordinates(s *bufio.Scanner, line *int) error {
+	if p.dimension == 0 {
+		return fmt.Errorf("dimension must be given before the node coordinates")
+	}
+
+	dimensions := 2
+	if p.edgeWeightType == "EUC_3D" || p.edgeWeightType == "MAN_3D" || p.edgeWeightType == "MAX_3D" {
+		dimensions = 3
+	}
+
+	p.coordinates = make([][]float64, p.dimension)
+	for i := 0; i < p.dimension; i++ {
+		numbers, err := p.numbers(s, line, 1+dimensions)
+		if err != nil {
+			return err
+		}
+
+		node := int(numbers[0])
+		if node < 1 || node > p.dimension {
+			return fmt.Errorf("node %d is not part of the problem", node)
+		} else if p.coordinates[node-1] != nil {
+			return fmt.Errorf("node %d has already coordinates", node)
+		}
+
+		p.coordinates[node-1] = numbers[1:]
+	}
+	p.hasNodeCoordinates = true
+
+	return nil
+}
+
+func (p *tsplibProblem) readEdgeWeights(s *bufio.Scanner, line *int) error {
+	n := p.dimension
+	if n == 0 {
+		return fmt.Errorf("dimension must be given before the edge weights")
+	}
+
+	var count int
+	switch p.edgeWeightFormat {
+	case "FULL_MATRIX":
+		count = n * n
+	case "UPPER_ROW", "LOWER_ROW", "UPPER_COL", "LOWER_COL":
+		count = n * (n - 1) / 2
+	case "UPPER_DIAG_ROW", "LOWER_DIAG_ROW", "UPPER_DIAG_COL", "LOWER_DIAG_COL":
+		count = n * (n + 1) / 2
+	default:
+		return fmt.Errorf("unsupported edge weight format %q", p.edgeWeightFormat)
+	}
+
+	var err error
+	p.edgeWeights, err = p.numbers(s, line, count)
+	if err != nil {
+		return err
+	}
+	for _, weight := range p.edgeWeights {
+		if weight < 0 {
+			return fmt.Errorf("edge weight must not be negative but is %v", weight)
+		}
+	}
+	p.hasEdgeWeights = true
+
+	return nil
+}
+
+// skipLines skips one line per node.
+func (p *tsplibProblem) skipLines(s *bufio.Scanner, line *int) error {
+	for i := 0; i < p.dimension; i++ {
+		if !s.Scan() {
+			return fmt.Errorf("section ended after %d of %d lines", i, p.dimension)
+		}
+		*line++
+	}
+
+	return nil
+}
+
+// skipUntilTerminator skips all lines until a line holding -1.
+func (p *tsplibProblem) skipUntilTerminator(s *bufio.Scanner, line *int) error {
+	for s.Scan() {
+		*line++
+
+		if strings.TrimSpace(s.Text()) == "-1" {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("section is not terminated by -1")
+}
+
+// graph returns the graph of the problem.
+func (p *tsplibProblem) graph() (*Graph, error) {
+	switch p.typ {
+	case "TSP", "ATSP":
+	case "":
+		return nil, fmt.Errorf("TSPLIB file has no type")
+	default:
+		return nil, fmt.Errorf("unsupported TSPLIB type %q", p.typ)
+	}
+	if p.dimension == 0 {
+		return nil, fmt.Errorf("TSPLIB file has no dimension")
+	}
+
+	n := p.dimension
+	g := NewGraph(n)
+
+	var weight func(from int, to int) int
+	if p.edgeWeightType == "EXPLICIT" {
+		if !p.hasEdgeWeights {
+			return nil, fmt.Errorf("TSPLIB file has no edge weights")
+		}
+
+		weights := p.explicitWeights()
+		weight = func(from int, to int) int {
+			return int(weights[from][to])
+		}
+	} else {
+		if !p.hasNodeCoordinates {
+			return nil, fmt.Errorf("TSPLIB file has no node coordinates")
+		}
+		for i, c := range p.coordinates {
+			if c == nil {
+				return nil, fmt.Errorf("node %d has no coordinates", i+1)
+			}
+		}
+
+		distance, err := tsplibDistance(p.edgeWeightType)
+		if err != nil {
+			return nil, err
+		}
+		weight = func(from int, to int) int {
+			return distance(p.coordinates[from], p.coordinates[to])
+		}
+	}
+
+	for y := 0; y < n; y++ {
+		for x := 0; x < n; x++ {
+			if x == y {
+				continue
+			}
+
+			g.SetEdge(y, x, weight(y, x))
+		}
+	}
+
+	return g, nil
+}
+
+// explicitWeights returns the full matrix of the explicit edge weights. All formats except FULL_MATRIX describe a symmetric matrix.
+func (p *tsplibProblem) explicitWeights() [][]float64 {
+	n := p.dimension
+	weights := make([][]float64, n)
+	for i := range weights {
+		weights[i] = make([]float64, n)
+	}
+
+	if p.edgeWeightFormat == "FULL_MATRIX" {
+		for y := 0; y < n; y++ {
+			copy(weights[y], p.edgeWeights[y*n:(y+1)*n])
+		}
+
+		return weights
+	}
+
+	// A column-wise format of the upper triangle is the row-wise format of the lower triangle and the other way around.
+	diagonal := strings.Contains(p.edgeWeightFormat, "DIAG")
+	upper := strings.HasPrefix(p.edgeWeightFormat, "UPPER")
+	if strings.HasSuffix(p.edgeWeightFormat, "_COL") {
+		upper = !upper
+	}
+
+	k := 0
+	for y := 0; y < n; y++ {
+		from, to := 0, y
+		if upper {
+			from, to = y, n-1
+		}
+		if !diagonal {
+			if upper {
+				from++
+			} else {
+				to--
+			}
+		}
+
+		for x := from; x <= to; x++ {
+			weights[y][x] = p.edgeWeights[k]
+			weights[x][y] = p.edgeWeights[k]
+			k++
+		}
+	}
+
+	return weights
+}
+
+// tsplibDistance returns the distance function of the given edge weight type as defined by TSPLIB.
+func tsplibDistance(edgeWeightType string) (func(a []float64, b []float64) int, error) {
+	nint := func(x float64) int {
+		return int(x + 0.5)
+	}
+
+	switch edgeWeightType {
+	case "EUC_2D", "EUC_3D":
+		return func(a []float64, b []float64) int {
+			sum := 0.0
+			for i := range a {
+				sum += (a[i] - b[i]) * (a[i] - b[i])
+			}
+
+			return nint(math.Sqrt(sum))
+		}, nil
+	case "MAN_2D", "MAN_3D":
+		return func(a []float64, b []float64) int {
+			sum := 0.0
+			for i := range a {
+				sum += math.Abs(a[i] - b[i])
+			}
+
+			return nint(sum)
+		}, nil
+	case "MAX_2D", "MAX_3D":
+		return func(a []float64, b []float64) int {
+			max := 0
+			for i := range a {
+				if d := nint(math.Abs(a[i] - b[i])); d > max {
+					max = d
+				}
+			}
+
+			return max
+		}, nil
+	case "CEIL_2D":
+		return func(a []float64, b []float64) int {
+			return int(math.Ceil(math.Hypot(a[0]-b[0], a[1]-b[1])))
+		}, nil
+	case "ATT":
+		// The pseudo-Euclidean distance.
+		return func(a []float64, b []float64) int {
+			xd, yd := a[0]-b[0], a[1]-b[1]
+			r := math.Sqrt((xd*xd + yd*yd) / 10.0)
+			t := nint(r)
+			if float64(t) < r {
+				return t + 1
+			}
+
+			return t
+		}, nil
+	case "GEO":
+		// Coordinates are given as degrees and minutes on an idealized sphere. The degrees are truncated as done by the reference implementation of TSPLIB.
+		radians := func(x float64) float64 {
+			const pi = 3.141592
+
+			degrees := float64(int(x))
+			minutes := x - degrees
+
+			return pi * (degrees + 5.0*minutes/3.0) / 180.0
+		}
+
+		return func(a []float64, b []float64) int {
+			const radius = 6378.388
+
+			latitudeA, longitudeA := radians(a[0]), radians(a[1])
+			latitudeB, longitudeB := radians(b[0]), radians(b[1])
+
+			q1 := math.Cos(longitudeA - longitudeB)
+			q2 := math.Cos(latitudeA - latitudeB)
+			q3 := math.Cos(latitudeA + latitudeB)
+
+			return int(radius*math.Acos(0.5*((1.0+q1)*q2-(1.0-q1)*q3)) + 1.0)
+		}, nil
+	case "":
+		return nil, fmt.Errorf("TSPLIB file has no edge weight type")
+	}
+
+	return nil, fmt.Errorf("unsupported edge weight type %q", edgeWeightType)
+}
@@ -0,0 +1,138 @@
+package tsp
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadTSPLIB(t *testing.T) {
+	g, err := ReadGraph("../graphs/burma14.tsp")
+	assert.NoError(t, err)
+	assert.Equal(t, 14, g.NumberOfNodes)
+
+	// The published optimum of burma14.
+	tour, err := (&Sequential{Bound: BoundReduced}).Solve(context.Background(), g)
+	assert.NoError(t, err)
+	assert.Equal(t, 3323, tour.Length)
+}
+
+func TestReadTSPLIBFormats(t *testing.T) {
+	directory, err := ioutil.TempDir("", "tsplib")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+
+	symmetric := [][]int{
+		{0, 1, 2, 3},
+		{1, 0, 4, 5},
+		{2, 4, 0, 6},
+		{3, 5, 6, 0},
+	}
+
+	for name, tc := range map[string]struct {
+		content  string
+		expected [][]int
+	}{
+		"FULL_MATRIX": {
+			"TYPE: ATSP\nDIMENSION: 3\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: FULL_MATRIX\nEDGE_WEIGHT_SECTION\n9999 1 2\n3 9999 4\n5 6 9999\nEOF\n",
+			[][]int{
+				{0, 1, 2},
+				{3, 0, 4},
+				{5, 6, 0},
+			},
+		},
+		"UPPER_ROW": {
+			"TYPE: TSP\nDIMENSION: 4\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: UPPER_ROW\nEDGE_WEIGHT_SECTION\n1 2 3\n4 5\n6\nEOF\n",
+			symmetric,
+		},
+		"LOWER_ROW": {
+			"TYPE: TSP\nDIMENSION: 4\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: LOWER_ROW\nEDGE_WEIGHT_SECTION\n1\n2 4\n3 5 6\nEOF\n",
+			symmetric,
+		},
+		"UPPER_DIAG_ROW": {
+			"TYPE: TSP\nDIMENSION: 4\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: UPPER_DIAG_ROW\nEDGE_WEIGHT_SECTION\n0 1 2 3 0 4 5 0 6 0\nEOF\n",
+			symmetric,
+		},
+		"LOWER_DIAG_ROW": {
+			"TYPE: TSP\nDIMENSION: 4\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: LOWER_DIAG_ROW\nEDGE_WEIGHT_SECTION\n0\n1 0\n2 4 0\n3 5 6 0\nEOF\n",
+			symmetric,
+		},
+		"UPPER_COL": {
+			"TYPE: TSP\nDIMENSION: 4\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: UPPER_COL\nEDGE_WEIGHT_SECTION\n1\n2 4\n3 5 6\nEOF\n",
+			symmetric,
+		},
+		"LOWER_DIAG_COL": {
+			"TYPE: TSP\nDIMENSION: 4\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: LOWER_DIAG_COL\nEDGE_WEIGHT_SECTION\n0 1 2 3\n0 4 5\n0 6\n0\nEOF\n",
+			symmetric,
+		},
+		"EUC_2D": {
+			"NAME : square\nTYPE : TSP\nDIMENSION : 3\nEDGE_WEIGHT_TYPE : EUC_2D\nNODE_COORD_SECTION\n1 0 0\n2 3 4\n3 0 2.6\nEOF\n",
+			[][]int{
+				{0, 5, 3},
+				{5, 0, 3},
+				{3, 3, 0},
+			},
+		},
+		"CEIL_2D": {
+			"TYPE: TSP\nDIMENSION: 2\nEDGE_WEIGHT_TYPE: CEIL_2D\nNODE_COORD_SECTION\n1 0 0\n2 1 1\n",
+			[][]int{
+				{0, 2},
+				{2, 0},
+			},
+		},
+		"ATT": {
+			"TYPE: TSP\nDIMENSION: 2\nEDGE_WEIGHT_TYPE: ATT\nNODE_COORD_SECTION\n2 0 0\n1 30 40\nEOF\n",
+			[][]int{
+				{0, 16},
+				{16, 0},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(directory, name)
+			assert.NoError(t, ioutil.WriteFile(file, []byte(tc.content), 0644))
+
+			g, err := ReadGraph(file)
+			assert.NoError(t, err)
+			if g != nil {
+				assert.Equal(t, tc.expected, g.Matrix)
+			}
+		})
+	}
+}
+
+func TestIsTSPLIB(t *testing.T) {
+	assert.True(t, isTSPLIB([]byte("NAME: burma14\nTYPE: TSP\n")))
+	assert.True(t, isTSPLIB([]byte("\ntype : atsp\n")))
+	assert.True(t, isTSPLIB([]byte("DIMENSION: 14\n")))
+	assert.True(t, isTSPLIB([]byte("COMMENT : 14-Staedte in Burma\n")))
+	assert.False(t, isTSPLIB([]byte("4\n0 1 3 8\n")))
+	// Malformed matrix files are no TSPLIB files either.
+	assert.False(t, isTSPLIB([]byte("four\n0 1 3 8\n")))
+	assert.False(t, isTSPLIB([]byte("x 1 2\n")))
+	assert.False(t, isTSPLIB([]byte("")))
+}
+
+func TestReadTSPLIBErrors(t *testing.T) {
+	directory, err := ioutil.TempDir("", "tsplib")
+	assert.NoError(t, err)
+	defer os.RemoveAll(directory)
+
+	for content, expected := range map[string]string{
+		"TYPE: HCP\nDIMENSION: 2\nEOF\n": `unsupported TSPLIB type "HCP"`,
+		"TYPE: TSP\nDIMENSION: 2\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: UPPER_ROW\nEOF\n":                         "TSPLIB file has no edge weights",
+		"TYPE: TSP\nDIMENSION: 3\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: UPPER_ROW\nEDGE_WEIGHT_SECTION\n1 2\n":    "line 6 of TSPLIB file: expected 3 numbers but found only 2",
+		"TYPE: TSP\nDIMENSION: 2\nSOMETHING: 1\n":                                                                           `line 3 of TSPLIB file: unknown keyword "SOMETHING"`,
+		"TYPE: TSP\nDIMENSION: 3\nEDGE_WEIGHT_TYPE: EXPLICIT\nEDGE_WEIGHT_FORMAT: UPPER_ROW\nEDGE_WEIGHT_SECTION\n1 -2 3\n": "line 6 of TSPLIB file: edge weight must not be negative but is -2",
+	} {
+		file := filepath.Join(directory, "problem")
+		assert.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+
+		_, err := ReadGraph(file)
+		assert.EqualError(t, err, expected)
+	}
+}